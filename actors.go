@@ -5,28 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
 // ContextHandler returns an actor, i.e. an execute and interrupt func, that
-// terminates when the provided context is canceled.
+// terminates when the provided context is canceled, with its cause.
 func ContextHandler(ctx context.Context) (execute func() error, interrupt func(error)) {
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
 	return func() error {
 			<-ctx.Done()
-			return ctx.Err()
-		}, func(error) {
-			cancel()
+			return context.Cause(ctx)
+		}, func(err error) {
+			cancel(err)
 		}
 }
 
 // SignalHandler returns an actor, i.e. an execute and interrupt func, that
 // terminates with ErrSignal when the process receives one of the provided
-// signals, or with ctx.Error() when the parent context is canceled. If no
-// signals are provided, the actor will terminate on any signal, per
+// signals, or with context.Cause(ctx) when the parent context is canceled.
+// If no signals are provided, the actor will terminate on any signal, per
 // [signal.Notify].
 func SignalHandler(ctx context.Context, signals ...os.Signal) (execute func() error, interrupt func(error)) {
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
 	return func() error {
 			testc := getTestSigChan(ctx)
 			sigc := make(chan os.Signal, 1)
@@ -38,10 +42,198 @@ func SignalHandler(ctx context.Context, signals ...os.Signal) (execute func() er
 			case sig := <-sigc:
 				return &SignalError{Signal: sig}
 			case <-ctx.Done():
-				return ctx.Err()
+				return context.Cause(ctx)
+			}
+		}, func(err error) {
+			cancel(err)
+		}
+}
+
+// PeriodicHandler returns an actor, i.e. an execute and interrupt func, that
+// calls fn whenever the schedule activates. The context passed to fn is
+// canceled on interrupt, with the teardown error as its cause, so a
+// long-running job can stop early. The actor terminates with the first error
+// returned by fn, or with context.Cause(ctx) when it is interrupted or the
+// parent context is canceled. The schedule follows the clock carried by ctx,
+// see ContextWithClock, or the system clock.
+func PeriodicHandler(ctx context.Context, schedule Schedule, fn func(context.Context) error) (execute func() error, interrupt func(error)) {
+	clock := clockFrom(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
+	return func() error {
+			for {
+				now := clock.Now()
+				next := schedule.Next(now)
+				if next.IsZero() {
+					<-ctx.Done()
+					return context.Cause(ctx)
+				}
+
+				timer := clock.NewTimer(next.Sub(now))
+				select {
+				case <-timer.C():
+				case <-ctx.Done():
+					timer.Stop()
+					return context.Cause(ctx)
+				}
+
+				if err := fn(ctx); err != nil {
+					return err
+				}
+			}
+		}, func(err error) {
+			cancel(err)
+		}
+}
+
+// TickerHandler is a PeriodicHandler that calls fn every d.
+func TickerHandler(ctx context.Context, d time.Duration, fn func(context.Context) error) (execute func() error, interrupt func(error)) {
+	return PeriodicHandler(ctx, Every(d), fn)
+}
+
+// FuncHandler returns an actor, i.e. an execute and interrupt func, that
+// runs an errgroup-style fn. The context passed to fn is derived from ctx
+// and canceled on interrupt, with the teardown error as its cause. The
+// actor terminates with the error returned by fn. Use AddTask instead for
+// functions that complete and should not tear down the group when they
+// return nil.
+func FuncHandler(ctx context.Context, fn func(context.Context) error) (execute func() error, interrupt func(error)) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return func() error {
+			return fn(ctx)
+		}, func(err error) {
+			cancel(err)
+		}
+}
+
+// WorkerPool returns an actor, i.e. an execute and interrupt func, that runs n
+// copies of fn concurrently. Each execution passes the workers a new context,
+// canceled on interrupt or as soon as one worker fails, with the teardown
+// error or the error of the worker as its cause. The actor terminates once
+// every worker has returned, with the first non-nil worker error, if any. An
+// interrupt before the first execution makes it return at once. WorkerPool
+// panics if n is not positive.
+func WorkerPool(n int, fn func(context.Context) error) (execute func() error, interrupt func(error)) {
+	if n <= 0 {
+		panic("deprun: non-positive worker count for WorkerPool")
+	}
+
+	var (
+		mu          sync.Mutex
+		cancel      context.CancelCauseFunc // of the running execution
+		started     bool
+		interrupted error // before the first execution
+	)
+	return func() error {
+			ctx, stop := context.WithCancelCause(context.Background())
+			defer stop(nil)
+
+			mu.Lock()
+			if !started && interrupted != nil {
+				stop(interrupted)
 			}
+			started, cancel = true, stop
+			mu.Unlock()
+
+			defer func() {
+				mu.Lock()
+				cancel = nil
+				mu.Unlock()
+			}()
+
+			var (
+				wg    sync.WaitGroup
+				once  sync.Once
+				first error
+			)
+
+			for range n {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					if err := fn(ctx); err != nil {
+						once.Do(func() {
+							first = err
+							stop(err)
+						})
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			return first
+		}, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case cancel != nil:
+				cancel(err)
+			case !started:
+				interrupted = err
+			}
+		}
+}
+
+// CommandHandler returns an actor, i.e. an execute and interrupt func, that
+// starts cmd and waits for it to exit. The actor terminates with the error
+// returned by cmd.Wait, so a non-zero exit status is reported as an
+// *exec.ExitError. On interrupt the process receives SIGTERM and, if it is
+// still running after grace, SIGKILL. On platforms without SIGTERM the
+// process is killed immediately. If interrupt is called before the process
+// was started, it is never started. The grace period follows the clock
+// carried by ctx, see ContextWithClock, or the system clock.
+func CommandHandler(ctx context.Context, cmd *exec.Cmd, grace time.Duration) (execute func() error, interrupt func(error)) {
+	var (
+		clock       = clockFrom(ctx)
+		mu          sync.Mutex
+		interrupted bool
+		exited      = make(chan struct{})
+	)
+	return func() error {
+			mu.Lock()
+			if interrupted {
+				mu.Unlock()
+				return nil
+			}
+			err := cmd.Start()
+			mu.Unlock()
+			if err != nil {
+				return err
+			}
+
+			defer close(exited)
+
+			return cmd.Wait()
 		}, func(error) {
-			cancel()
+			mu.Lock()
+			defer mu.Unlock()
+
+			if interrupted {
+				return
+			}
+
+			interrupted = true
+			if cmd.Process == nil {
+				return
+			}
+
+			if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+				_ = cmd.Process.Kill()
+				return
+			}
+
+			go func() {
+				timer := clock.NewTimer(grace)
+				defer timer.Stop()
+
+				select {
+				case <-exited:
+				case <-timer.C():
+					_ = cmd.Process.Kill()
+				}
+			}()
 		}
 }
 
@@ -76,9 +268,10 @@ func (e SignalError) Error() string {
 }
 
 // Is addresses a design error in the SignalError type, so that errors.Is with
-// ErrSignal will return true.
+// ErrSignal will return true. It also matches ErrInterrupted: a signal asks
+// for a clean shutdown.
 func (e SignalError) Is(err error) bool {
-	return errors.Is(err, ErrSignal)
+	return errors.Is(err, ErrSignal) || err == ErrInterrupted
 }
 
 // As fixes a design error in the SignalError type, so that errors.As with the
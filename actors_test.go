@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"os/exec"
 	"testing"
 	"time"
 )
@@ -65,3 +66,142 @@ func TestSignalHandlerNil(t *testing.T) {
 	cancel()
 	t.Logf("%v", rg.Run())
 }
+
+func TestTickerHandler(t *testing.T) {
+	var calls int
+	myError := errors.New("done")
+	var rg Group
+	rg.Add(TickerHandler(context.Background(), time.Millisecond, func(context.Context) error {
+		if calls++; calls == 3 {
+			return myError
+		}
+		return nil
+	}))
+	if want, have := myError, rg.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := 3, calls; want != have {
+		t.Errorf("calls: want %d, have %d", want, have)
+	}
+}
+
+func TestTickerHandlerInterrupt(t *testing.T) {
+	myError := errors.New("teardown")
+	var rg Group
+	rg.Add(TickerHandler(context.Background(), time.Hour, func(context.Context) error {
+		t.Errorf("job ran unexpectedly")
+		return nil
+	}))
+	rg.Add(func() error { return myError }, func(error) {})
+	if want, have := myError, rg.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	myError := errors.New("worker failed")
+	started := make(chan struct{}, 4)
+	var rg Group
+	rg.Add(WorkerPool(4, func(ctx context.Context) error {
+		started <- struct{}{}
+		if len(started) == 4 {
+			return myError
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	if want, have := myError, rg.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestWorkerPoolInterrupt(t *testing.T) {
+	myError := errors.New("teardown")
+	var rg Group
+	rg.Add(WorkerPool(3, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}))
+	rg.Add(func() error { return myError }, func(error) {})
+	if want, have := myError, rg.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestWorkerPoolRerun(t *testing.T) {
+	running := make(chan struct{})
+	execute, interrupt := WorkerPool(2, func(ctx context.Context) error {
+		running <- struct{}{}
+		<-ctx.Done()
+		return nil
+	})
+
+	for range 2 {
+		done := make(chan error, 1)
+		go func() { done <- execute() }()
+
+		<-running
+		<-running
+		interrupt(errors.New("teardown"))
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("want no error, have %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("pool not interrupted")
+		}
+	}
+}
+
+func TestWorkerPoolInterruptBeforeStart(t *testing.T) {
+	execute, interrupt := WorkerPool(2, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	interrupt(errors.New("teardown"))
+	if err := execute(); err != nil {
+		t.Errorf("want no error, have %v", err)
+	}
+}
+
+func TestWorkerPoolNonPositive(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic")
+		}
+	}()
+
+	WorkerPool(0, func(context.Context) error { return nil })
+}
+
+func TestCommandHandlerExitStatus(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	var rg Group
+	rg.Add(CommandHandler(context.Background(), exec.Command("sh", "-c", "exit 3"), time.Second))
+	var exitErr *exec.ExitError
+	if err := rg.Run(); !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("want exit status 3, have %v", err)
+	}
+}
+
+func TestCommandHandlerKillAfterGrace(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	myError := errors.New("teardown")
+	var rg Group
+	rg.Add(CommandHandler(context.Background(), exec.Command("sh", "-c", "trap '' TERM; sleep 10"), 50*time.Millisecond))
+	rg.Add(func() error { time.Sleep(50 * time.Millisecond); return myError }, func(error) {})
+	begin := time.Now()
+	if want, have := myError, rg.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if elapsed := time.Since(begin); elapsed > 5*time.Second {
+		t.Errorf("process was not killed after grace period (%v)", elapsed)
+	}
+}
@@ -0,0 +1,127 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Spec describes an actor added with AddAll.
+type Spec struct {
+	Name string // see Name; lets other specs depend on the actor
+
+	// Execute is the execute func of an actor that is ready as soon as it
+	// starts, like one added with Add; ExecuteReady that of an actor that
+	// signals when it is ready, like one added with AddDep. Exactly one of
+	// them must be set.
+	Execute      func() error
+	ExecuteReady func(ready ReadySignal) error
+
+	Interrupt func(error) // may be nil, see Add
+
+	// DependsOn names the actors the actor depends on, among the specs of
+	// the batch, in any order, and the actors added before. Other
+	// dependencies are passed in Options, with DependsOn.
+	DependsOn []string
+
+	Options []ActorOption
+}
+
+// AddAll adds the actors described by specs, e.g. built from configuration
+// or in a loop, and returns the Dependencies they provide, in the order of
+// specs.
+//
+// The batch is validated as a whole before any actor is added. AddAll
+// reports, joined:
+//
+//   - specs without exactly one of Execute and ExecuteReady;
+//   - names given to several actors, matching ErrDuplicateName;
+//   - names in DependsOn that no actor has, matching ErrUnknownDependency;
+//   - dependency cycles among the specs, matching ErrCycle.
+//
+// If it returns an error, no actor was added.
+func (g *Group) AddAll(specs ...Spec) ([]*Dependency, error) {
+	registered := g.registered()
+
+	var errs []error
+
+	names := make(map[string]*Dependency)
+	for i := range registered {
+		if name := registered[i].name; name != "" && names[name] == nil {
+			names[name] = registered[i].provides
+		}
+	}
+
+	batch := make([]actor, len(specs))
+	for i, s := range specs {
+		batch[i].provides = newDependency()
+
+		if s.Name == "" {
+			continue
+		}
+
+		if names[s.Name] != nil {
+			errs = append(errs, fmt.Errorf("deprun: %s: %w", s.Name, ErrDuplicateName))
+
+			continue
+		}
+
+		names[s.Name] = batch[i].provides
+	}
+
+	for i, s := range specs {
+		a := &batch[i]
+		a.index = len(registered) + i
+		a.interrupt = s.Interrupt
+		if s.ExecuteReady != nil {
+			a.execute, a.provider = s.ExecuteReady, true
+		} else {
+			a.execute = addExecute(s.Execute)
+		}
+
+		var (
+			deps    []*Dependency
+			unknown []string
+		)
+		for _, name := range s.DependsOn {
+			if d := names[name]; d != nil {
+				deps = append(deps, d)
+			} else {
+				unknown = append(unknown, name)
+			}
+		}
+
+		opts := []ActorOption{DependsOn(deps...)}
+		if s.Name != "" {
+			opts = append(opts, Name(s.Name))
+		}
+		g.prepare(a, append(opts, s.Options...))
+
+		if (s.Execute == nil) == (s.ExecuteReady == nil) {
+			errs = append(errs, fmt.Errorf("deprun: %s: want exactly one of Execute and ExecuteReady", a.qualified()))
+		}
+
+		if len(unknown) > 0 {
+			errs = append(errs, fmt.Errorf("deprun: %s: %s: %w", a.qualified(), strings.Join(unknown, ", "), ErrUnknownDependency))
+		}
+	}
+
+	for _, cycle := range cycles(batch, providerIndices(batch)) {
+		errs = append(errs, cycleError(batch, cycle))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	deps := make([]*Dependency, len(batch))
+	g.update("actors added", func() {
+		for i := range batch {
+			batch[i].index = len(g.actors)
+			g.actors = append(g.actors, batch[i])
+			deps[i] = batch[i].provides
+		}
+	})
+
+	return deps, nil
+}
@@ -0,0 +1,92 @@
+package deprun_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestAddAll(t *testing.T) {
+	var (
+		g       deprun.Group
+		dbReady atomic.Bool
+	)
+
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("config"), deprun.NonCritical())
+
+	stop := make(chan struct{})
+	myError := errors.New("done")
+	deps, err := g.AddAll(
+		deprun.Spec{
+			Name: "api",
+			Execute: func() error {
+				if !dbReady.Load() {
+					t.Error("api started before db was ready")
+				}
+
+				return myError
+			},
+			DependsOn: []string{"db", "config"},
+		},
+		deprun.Spec{
+			Name: "db",
+			ExecuteReady: func(ready deprun.ReadySignal) error {
+				dbReady.Store(true)
+				ready()
+				<-stop
+
+				return nil
+			},
+			Interrupt: func(error) { close(stop) },
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 2, len(deps); want != have {
+		t.Fatalf("want %d dependencies, have %d", want, have)
+	}
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := deprun.DependencyReady, deps[1].State(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestAddAllErrors(t *testing.T) {
+	var g deprun.Group
+
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("db"))
+
+	run := func() error { return nil }
+	_, err := g.AddAll(
+		deprun.Spec{Name: "db", Execute: run},
+		deprun.Spec{Name: "api", Execute: run, DependsOn: []string{"cache"}},
+		deprun.Spec{Name: "a", Execute: run, DependsOn: []string{"b"}},
+		deprun.Spec{Name: "b", Execute: run, DependsOn: []string{"a"}},
+		deprun.Spec{Name: "worker"},
+	)
+
+	for _, target := range []error{deprun.ErrDuplicateName, deprun.ErrUnknownDependency, deprun.ErrCycle} {
+		if !errors.Is(err, target) {
+			t.Errorf("want %v, have %v", target, err)
+		}
+	}
+
+	for _, want := range []string{"api: cache", "a -> b -> a", "worker: want exactly one"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("want %q in %v", want, err)
+		}
+	}
+
+	if want, have := 1, len(g.States()); want != have {
+		t.Errorf("want %d actor, have %d", want, have)
+	}
+}
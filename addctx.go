@@ -0,0 +1,78 @@
+package deprun
+
+import "context"
+
+// WithBaseContext sets the context the contexts of actors added with AddCtx
+// derive from, e.g. to pass them values. Canceling it cancels them all. It
+// defaults to context.Background().
+func WithBaseContext(ctx context.Context) Option {
+	return func(g *Group) {
+		g.baseCtx = ctx
+	}
+}
+
+// AddCtx adds an actor like Add, for context-aware code: the group passes
+// execute a context of its own, derived from the base context, see
+// WithBaseContext, and cancels it on interrupt, with the teardown error as
+// its cause. No interrupt function is needed:
+//
+//	g.AddCtx(func(ctx context.Context) error {
+//		return consumer.Run(ctx)
+//	}, db)
+//
+// The actor counts as ready once it starts. Each execution gets a new
+// context: an actor restarted within a run, e.g. with RestartSubtree, does
+// not inherit the canceled context of its previous execution.
+func (g *Group) AddCtx(execute func(ctx context.Context) error, opts ...ActorOption) {
+	g.add(actor{ctxExecute: execute}, opts)
+}
+
+// armContext sets the execute and interrupt functions of an actor added
+// with AddCtx or AddTask for its first execution in the current run, see
+// contextExecution.
+func (g *Group) armContext(a *actor) {
+	if a.ctxExecute == nil {
+		return
+	}
+
+	a.execute, a.interrupt = g.contextExecution(a)
+}
+
+// contextExecution returns the execute and interrupt functions of a single
+// execution of an actor added with AddCtx or AddTask: interrupt cancels the
+// context passed to that execution only.
+func (g *Group) contextExecution(a *actor) (execute func(ReadySignal) error, interrupt func(error)) {
+	base := g.baseCtx
+	if base == nil {
+		base = context.Background()
+	}
+
+	ctx, cancel := context.WithCancelCause(context.WithValue(base, actorKey{}, a.info()))
+	run := a.ctxExecute
+	if a.task {
+		execute = func(ReadySignal) error { return run(ctx) }
+	} else {
+		execute = addExecute(func() error { return run(ctx) })
+	}
+
+	return execute, func(err error) { cancel(err) }
+}
+
+type actorKey struct{}
+
+// ActorFromContext returns the actor that the group passed ctx to, with
+// AddCtx or AddTask, so that logging and tracing in actor code can identify
+// it without extra plumbing. It reports false for other contexts.
+func ActorFromContext(ctx context.Context) (ActorInfo, bool) {
+	info, ok := ctx.Value(actorKey{}).(ActorInfo)
+
+	return info, ok
+}
+
+// WithGroupName names the group, for ActorFromContext and observers, e.g.
+// to tell apart the actors of several groups in logs.
+func WithGroupName(name string) Option {
+	return func(g *Group) {
+		g.name = name
+	}
+}
@@ -0,0 +1,78 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+type baseKey struct{}
+
+func TestAddCtx(t *testing.T) {
+	base := context.WithValue(context.Background(), baseKey{}, "base")
+	g := deprun.New(deprun.WithBaseContext(base))
+
+	stop := make(chan struct{}, 1)
+	db := g.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { stop <- struct{}{} })
+
+	var values, causes []any
+	g.AddCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		values = append(values, ctx.Value(baseKey{}))
+		causes = append(causes, context.Cause(ctx))
+
+		return nil
+	}, db)
+
+	boom := errors.New("boom")
+	g.Add(func() error { return boom }, func(error) {}, db)
+
+	// Every run gets a new context.
+	for range 2 {
+		if err := g.Clone().Run(); err != boom {
+			t.Errorf("want %v, have %v", boom, err)
+		}
+	}
+
+	if want, have := "[base base] [boom boom]", fmt.Sprint(values, causes); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestActorFromContext(t *testing.T) {
+	g := deprun.New(deprun.WithGroupName("billing"))
+
+	infos := make(chan deprun.ActorInfo, 2)
+	g.AddTask(func(ctx context.Context) error {
+		info, _ := deprun.ActorFromContext(ctx)
+		infos <- info
+
+		return nil
+	}, deprun.Name("migrate"), deprun.Tags("db"))
+	g.AddCtx(func(ctx context.Context) error {
+		info, _ := deprun.ActorFromContext(ctx)
+		infos <- info
+
+		return nil
+	}, deprun.Name("api"))
+
+	g.Run()
+
+	have := []string{fmt.Sprint(<-infos), fmt.Sprint(<-infos)}
+	slices.Sort(have)
+	if want := []string{"{0 migrate [db] billing map[]}", "{1 api [] billing map[]}"}; !slices.Equal(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if _, ok := deprun.ActorFromContext(context.Background()); ok {
+		t.Error("want no actor in a background context")
+	}
+}
@@ -0,0 +1,104 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBreakerOpen is reported by Health for an actor whose CircuitBreaker is
+// open.
+var ErrBreakerOpen = errors.New("circuit breaker open")
+
+// CircuitBreaker stops restarting an actor with RestartSubtree for cooldown
+// once it failed failures times within window, so that a restart storm
+// does not hammer downstream systems. After the cooldown the actor is
+// restarted once more; if it fails again within window, the breaker opens
+// again at once. The breaker does not lift the limit of
+// RetryPolicy.MaxAttempts, which should be large when a breaker is used.
+//
+// Health reports a status named "breaker: " followed by the name of the
+// actor, with an error matching ErrBreakerOpen while the breaker is open.
+func CircuitBreaker(failures int, window, cooldown time.Duration) ActorOption {
+	return actorOption(func(a *actor) {
+		a.breaker = &breaker{threshold: failures, window: window, cooldown: cooldown}
+	})
+}
+
+// breaker is the state of a CircuitBreaker, shared by all copies of the
+// actor.
+type breaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures []time.Time // recent failures, oldest first
+	until    time.Time   // the breaker is open until then
+	probing  time.Time   // a failure before then opens the breaker again
+}
+
+// failed records a failure and returns how long to wait before the next
+// restart: the cooldown if the breaker opens, and zero otherwise.
+func (b *breaker) failed(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Before(b.probing) {
+		return b.open(now)
+	}
+
+	b.failures = append(b.failures, now)
+	for len(b.failures) > 0 && now.Sub(b.failures[0]) > b.window {
+		b.failures = b.failures[1:]
+	}
+
+	if len(b.failures) < b.threshold {
+		return 0
+	}
+
+	return b.open(now)
+}
+
+// open opens the breaker from now. b.mu must be held.
+func (b *breaker) open(now time.Time) time.Duration {
+	b.failures = nil
+	b.until = now.Add(b.cooldown)
+	b.probing = b.until.Add(b.window)
+
+	return b.cooldown
+}
+
+// reset closes the breaker when a new run starts.
+func (b *breaker) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures, b.until, b.probing = nil, time.Time{}, time.Time{}
+}
+
+// err returns an error matching ErrBreakerOpen if the breaker is open at
+// now.
+func (b *breaker) err(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !now.Before(b.until) {
+		return nil
+	}
+
+	return fmt.Errorf("%w until %s", ErrBreakerOpen, b.until.Format(time.RFC3339))
+}
+
+// breakerStatuses returns the health of the circuit breakers of actors.
+func breakerStatuses(actors []actor, now time.Time) []HealthStatus {
+	var statuses []HealthStatus
+	for i := range actors {
+		if b := actors[i].breaker; b != nil {
+			statuses = append(statuses, HealthStatus{Name: "breaker: " + actors[i].String(), Err: b.err(now)})
+		}
+	}
+
+	return statuses
+}
@@ -0,0 +1,55 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		g        deprun.Group
+		failures atomic.Int32
+	)
+
+	myError := errors.New("flapping")
+	g.AddDepWith(func(deprun.ReadySignal) error {
+		failures.Add(1)
+		return myError
+	}, func(error) {}, deprun.Name("client"), deprun.CircuitBreaker(3, time.Second, time.Hour), deprun.RestartSubtree(deprun.RetryPolicy{
+		MaxAttempts: 100,
+		Backoff:     time.Millisecond,
+	}))
+
+	done := errors.New("done")
+	g.Add(func() error {
+		await(t, func() bool {
+			_, err := g.Health(context.Background())
+			return errors.Is(err, deprun.ErrBreakerOpen)
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		if want, have := int32(3), failures.Load(); want != have {
+			t.Errorf("want %d failures, have %d", want, have)
+		}
+
+		statuses, _ := g.Health(context.Background())
+		if want, have := "breaker: client", statuses[0].Name; want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+
+		return done
+	}, func(error) {})
+
+	if want, have := done, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if _, err := g.Health(context.Background()); err == nil {
+		t.Error("breaker closed after the run")
+	}
+}
@@ -0,0 +1,81 @@
+package deprun
+
+// ActorBuilder registers an actor step by step, see Group.Actor. Its methods
+// return the builder, so that calls can be chained.
+type ActorBuilder struct {
+	g         *Group
+	name      string
+	execute   func(ReadySignal) error
+	provider  bool
+	interrupt func(error)
+	opts      []ActorOption
+}
+
+// Actor starts building an actor with the given name, as a readable
+// alternative to Add and AddDep with many options:
+//
+//	cache := g.Actor("cache").
+//		Execute(c.Run).
+//		Interrupt(c.Stop).
+//		DependsOn(db).
+//		NonCritical().
+//		Register()
+//
+// The actor is only added to the group by Register.
+func (g *Group) Actor(name string) *ActorBuilder {
+	return &ActorBuilder{g: g, name: name}
+}
+
+// Execute sets the execute func of the actor. Like an actor added with Add,
+// it is ready as soon as it starts.
+func (b *ActorBuilder) Execute(execute func() error) *ActorBuilder {
+	b.execute = addExecute(execute)
+	b.provider = false
+
+	return b
+}
+
+// ExecuteReady sets the execute func of an actor that signals when it is
+// ready, like an actor added with AddDep.
+func (b *ActorBuilder) ExecuteReady(execute func(ready ReadySignal) error) *ActorBuilder {
+	b.execute = execute
+	b.provider = true
+
+	return b
+}
+
+// Interrupt sets the interrupt func of the actor.
+func (b *ActorBuilder) Interrupt(interrupt func(error)) *ActorBuilder {
+	b.interrupt = interrupt
+
+	return b
+}
+
+// DependsOn makes the actor depend on deps, see the DependsOn option.
+func (b *ActorBuilder) DependsOn(deps ...*Dependency) *ActorBuilder {
+	return b.With(DependsOn(deps...))
+}
+
+// NonCritical marks the actor as non-critical, see the NonCritical option.
+func (b *ActorBuilder) NonCritical() *ActorBuilder {
+	return b.With(NonCritical())
+}
+
+// With applies further actor options.
+func (b *ActorBuilder) With(opts ...ActorOption) *ActorBuilder {
+	b.opts = append(b.opts, opts...)
+
+	return b
+}
+
+// Register adds the actor to the group and returns the Dependency it
+// provides. It panics if no execute func was set.
+func (b *ActorBuilder) Register() *Dependency {
+	if b.execute == nil {
+		panic("deprun: actor " + b.name + " registered without an execute func")
+	}
+
+	opts := append([]ActorOption{Name(b.name)}, b.opts...)
+
+	return b.g.add(actor{execute: b.execute, interrupt: b.interrupt, provider: b.provider}, opts)
+}
@@ -0,0 +1,66 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestActorBuilder(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	db := g.Actor("db").
+		ExecuteReady(func(ready deprun.ReadySignal) error {
+			ready()
+			<-stop
+			return nil
+		}).
+		Interrupt(func(error) { close(stop) }).
+		Register()
+
+	cache := g.Actor("cache").
+		Execute(func() error { return errors.New("cache failed") }).
+		Interrupt(func(error) {}).
+		DependsOn(db).
+		NonCritical().
+		Register()
+
+	myError := errors.New("done")
+	g.Actor("api").
+		Execute(func() error {
+			<-cache.Done()
+			return myError
+		}).
+		Interrupt(func(error) {}).
+		With(deprun.DependsOn(db)).
+		Register()
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	states := g.States()
+	if want, have := 3, len(states); want != have {
+		t.Fatalf("want %d actors, have %d", want, have)
+	}
+
+	for i, want := range []string{"db", "cache", "api"} {
+		if have := states[i].Name; want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+	}
+}
+
+func TestActorBuilderWithoutExecute(t *testing.T) {
+	var g deprun.Group
+
+	defer func() {
+		if want, have := "deprun: actor api registered without an execute func", recover(); want != have {
+			t.Errorf("want panic %q, have %v", want, have)
+		}
+	}()
+
+	g.Actor("api").Interrupt(func(error) {}).Register()
+}
@@ -0,0 +1,121 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+)
+
+// ErrChaos is matched, via errors.Is, by the error of an actor failed by
+// WithChaos.
+var ErrChaos = errors.New("chaos failure")
+
+// Chaos configures WithChaos. The zero value injects nothing.
+type Chaos struct {
+	// Seed makes the injected faults reproducible: the same seed and
+	// registrations yield the same delays and failures, though not the
+	// same interleaving of goroutines.
+	Seed uint64
+
+	// MaxStartDelay delays the start of each actor, once its dependencies
+	// are ready, by a random duration up to it.
+	MaxStartDelay time.Duration
+
+	// MaxReadyDelay delays each ready signal by a random duration up to it.
+	// The ready function blocks meanwhile.
+	MaxReadyDelay time.Duration
+
+	// FailureRate is the probability, from 0 to 1, that an actor is
+	// interrupted and fails with an error matching ErrChaos, a random
+	// duration up to MaxFailureDelay after it started.
+	FailureRate     float64
+	MaxFailureDelay time.Duration
+}
+
+// WithChaos injects random faults into the runs of the group, to exercise
+// teardown paths and interrupt functions in tests: actors start late,
+// become ready late, and fail. It is meant for tests only.
+func WithChaos(c Chaos) Option {
+	return func(g *Group) {
+		g.chaos = &c
+	}
+}
+
+// chaosPlan is the faults injected into an actor during a run.
+type chaosPlan struct {
+	startDelay time.Duration
+	readyDelay time.Duration
+	fail       bool
+	failDelay  time.Duration
+}
+
+// planChaos draws the faults of each actor, in registration order.
+func (g *Group) planChaos(actors []actor) {
+	c := g.chaos
+	if c == nil {
+		return
+	}
+
+	r := rand.New(rand.NewPCG(c.Seed, c.Seed))
+	upTo := func(d time.Duration) time.Duration {
+		return time.Duration(r.Int64N(int64(max(d, 0)) + 1))
+	}
+
+	for i := range actors {
+		a := &actors[i]
+		if a.hidden {
+			continue
+		}
+
+		a.chaos = &chaosPlan{
+			startDelay: upTo(c.MaxStartDelay),
+			readyDelay: upTo(c.MaxReadyDelay),
+			fail:       r.Float64() < c.FailureRate,
+			failDelay:  upTo(c.MaxFailureDelay),
+		}
+	}
+}
+
+// chaosDelay sleeps for d, and reports false if stop is closed first.
+func chaosDelay(clock Clock, d time.Duration, stop <-chan struct{}) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// startChaos fails a after its failure delay, if it is to fail. The
+// returned stop function must be called once execute returns; it reports
+// whether the failure was injected.
+func (g *Group) startChaos(a *actor) (stop func() (failed bool)) {
+	if a.chaos == nil || !a.chaos.fail {
+		return func() bool { return false }
+	}
+
+	var fired atomic.Bool
+	timer := a.clock.AfterFunc(a.chaos.failDelay, func() {
+		fired.Store(true)
+		g.interruptActor(a, a.chaosError())
+	})
+
+	return func() bool {
+		timer.Stop()
+
+		return fired.Load()
+	}
+}
+
+func (a *actor) chaosError() error {
+	return fmt.Errorf("deprun: %s: %w", a.qualified(), ErrChaos)
+}
@@ -0,0 +1,70 @@
+package deprun_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestWithChaos(t *testing.T) {
+	for seed := range uint64(20) {
+		g := deprun.New(deprun.WithChaos(deprun.Chaos{
+			Seed:            seed,
+			MaxStartDelay:   2 * time.Millisecond,
+			MaxReadyDelay:   2 * time.Millisecond,
+			FailureRate:     0.3,
+			MaxFailureDelay: 5 * time.Millisecond,
+		}))
+
+		var running atomic.Int32
+		var deps []*deprun.Dependency
+		for range 5 {
+			stop := make(chan struct{})
+			deps = append(deps, g.AddDep(func(ready deprun.ReadySignal) error {
+				running.Add(1)
+				defer running.Add(-1)
+
+				ready()
+				<-stop
+
+				return nil
+			}, func(error) { close(stop) }))
+		}
+
+		stop := make(chan struct{})
+		g.AddWith(func() error {
+			select {
+			case <-stop:
+			case <-time.After(20 * time.Millisecond):
+			}
+			return nil
+		}, func(error) { close(stop) }, deprun.DependsOn(deps...))
+
+		// Whatever fails, the group stops, and every actor with it.
+		err := g.Run()
+		if err != nil && !errors.Is(err, deprun.ErrChaos) && !errors.Is(err, deprun.ErrNeverStarted) {
+			t.Errorf("seed %d: unexpected error %v", seed, err)
+		}
+
+		if have := running.Load(); have != 0 {
+			t.Errorf("seed %d: %d actors still running", seed, have)
+		}
+	}
+}
+
+func TestWithChaosFailure(t *testing.T) {
+	g := deprun.New(deprun.WithChaos(deprun.Chaos{FailureRate: 1}))
+
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+
+	if err := g.Run(); !errors.Is(err, deprun.ErrChaos) {
+		t.Errorf("want %v, have %v", deprun.ErrChaos, err)
+	}
+}
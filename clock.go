@@ -0,0 +1,114 @@
+package deprun
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time of a group: its timeouts, backoffs and
+// watchdogs all go through it, see WithClock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by a Clock, see time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// WithClock makes the group measure time with clock instead of the system
+// clock, e.g. to test timeout behavior with a fake clock, without real
+// sleeps. The dependencies returned by After, AtNext, Probe and When use it
+// too, and observers get it with OnGroupStart. Handlers created outside the
+// group, such as PeriodicHandler, take it from their context, see
+// ContextWithClock. The package also works under testing/synctest with the
+// system clock.
+func WithClock(clock Clock) Option {
+	return func(g *Group) {
+		g.clock = clock
+	}
+}
+
+// systemClock is the Clock of the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return systemTimer{time.AfterFunc(d, f)}
+}
+
+type systemTimer struct{ *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
+
+// clockOrSystem returns the clock of the group.
+func (g *Group) clockOrSystem() Clock {
+	if g.clock == nil {
+		return systemClock{}
+	}
+
+	return g.clock
+}
+
+type clockKey struct{}
+
+// ContextWithClock returns a copy of ctx carrying clock, for the handlers
+// taking a context, such as PeriodicHandler and TickerHandler, to measure time
+// with clock instead of the system clock.
+func ContextWithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// clockFrom returns the clock carried by ctx, or the system clock.
+func clockFrom(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return clock
+	}
+
+	return systemClock{}
+}
+
+// withTimeout is context.WithTimeout measuring d with clock: the context is
+// canceled with context.DeadlineExceeded as its cause once d elapsed.
+func withTimeout(ctx context.Context, clock Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	deadline := clock.Now().Add(d)
+	ctx, cancel := context.WithCancelCause(ctx)
+	timer := clock.AfterFunc(d, func() { cancel(context.DeadlineExceeded) })
+
+	return deadlineContext{ctx, deadline}, func() {
+		timer.Stop()
+		cancel(nil)
+	}
+}
+
+// deadlineContext reports the deadline of a context built by withTimeout,
+// and context.DeadlineExceeded once it passed, like context.WithTimeout.
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c deadlineContext) Deadline() (time.Time, bool) { return c.deadline, true }
+
+func (c deadlineContext) Err() error {
+	err := c.Context.Err()
+	if err != nil && context.Cause(c.Context) == context.DeadlineExceeded {
+		return context.DeadlineExceeded
+	}
+
+	return err
+}
+
+// since returns the time elapsed since t according to clock.
+func since(clock Clock, t time.Time) time.Duration {
+	return clock.Now().Sub(t)
+}
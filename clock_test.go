@@ -0,0 +1,287 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+// fakeClock is a Clock whose time only moves on Advance.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	c     chan time.Time
+	f     func()
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) deprun.Timer {
+	return c.add(d, &fakeTimer{clock: c, c: make(chan time.Time, 1)})
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) deprun.Timer {
+	return c.add(d, &fakeTimer{clock: c, f: f})
+}
+
+func (c *fakeClock) add(d time.Duration, t *fakeTimer) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t.at = c.now.Add(d)
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Advance moves the time forward by d and fires the timers due by then.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+
+	var due []*fakeTimer
+	for i := 0; i < len(c.timers); i++ {
+		if t := c.timers[i]; !t.at.After(c.now) {
+			due = append(due, t)
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			i--
+		}
+	}
+	now := c.now
+	c.mu.Unlock()
+
+	for _, t := range due {
+		if t.f != nil {
+			go t.f()
+		} else {
+			t.c <- now
+		}
+	}
+}
+
+// Pending returns the number of timers not fired yet.
+func (c *fakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, other := range t.clock.timers {
+		if other == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	active := t.Stop()
+	t.clock.add(d, t)
+
+	return active
+}
+
+func TestWithClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	g := deprun.New(deprun.WithClock(clock), deprun.WithStartupTimeout(time.Hour))
+
+	stop := make(chan struct{})
+	g.AddDepWith(func(deprun.ReadySignal) error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	errc := make(chan error, 1)
+	go func() { errc <- g.Run() }()
+
+	await(t, func() bool { return clock.Pending() > 0 })
+	clock.Advance(59 * time.Minute)
+
+	select {
+	case err := <-errc:
+		t.Fatalf("want no error before the timeout, have %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Minute)
+
+	var timeout *deprun.StartupTimeoutError
+	if err := <-errc; !errors.As(err, &timeout) {
+		t.Fatalf("want %T, have %v", timeout, err)
+	}
+
+	if want, have := time.Hour, timeout.Timeout; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestSynctest(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		g := deprun.New()
+		start := time.Now()
+
+		var attempts int
+		var done time.Duration
+		g.AddTask(func(context.Context) error {
+			if attempts++; attempts < 3 {
+				return errors.New("transient")
+			}
+			done = time.Since(start)
+
+			return nil
+		}, deprun.Retry(deprun.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}))
+
+		stop := make(chan struct{})
+		g.AddWith(func() error {
+			<-stop
+			return nil
+		}, func(error) { close(stop) }, deprun.Timeout(time.Hour))
+
+		var timeout *deprun.ActorTimeoutError
+		if err := g.Run(); !errors.As(err, &timeout) {
+			t.Fatalf("want %T, have %v", timeout, err)
+		}
+
+		if want, have := 3*time.Minute, done; want != have {
+			t.Errorf("want task done after %v, have %v", want, have)
+		}
+
+		if want, have := time.Hour, time.Since(start); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	})
+}
+
+func TestObserverClock(t *testing.T) {
+	now := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	clock := &fakeClock{now: now}
+	g := deprun.New(deprun.WithClock(clock))
+	events := g.Events()
+
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("job"))
+
+	times := make(chan []time.Time)
+	go func() {
+		var have []time.Time
+		for e := range events {
+			have = append(have, e.Time)
+		}
+		times <- have
+	}()
+
+	report, err := g.RunReport()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := report.Actors[0]
+	for _, have := range []time.Time{report.Start, report.End, report.Teardown, job.Started, job.Ready, job.Exited} {
+		if !have.Equal(now) {
+			t.Errorf("report: want %v, have %v", now, have)
+		}
+	}
+
+	for _, have := range <-times {
+		if !have.Equal(now) {
+			t.Errorf("event: want %v, have %v", now, have)
+		}
+	}
+}
+
+func TestPeriodicHandlerClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	ctx := deprun.ContextWithClock(context.Background(), clock)
+
+	ran := make(chan struct{})
+	execute, interrupt := deprun.TickerHandler(ctx, time.Hour, func(context.Context) error {
+		close(ran)
+		return errors.New("done")
+	})
+
+	errc := make(chan error, 1)
+	go func() { errc <- execute() }()
+	defer interrupt(nil)
+
+	await(t, func() bool { return clock.Pending() == 1 })
+	clock.Advance(time.Hour)
+
+	<-ran
+	if err := <-errc; err == nil || err.Error() != "done" {
+		t.Errorf("want done, have %v", err)
+	}
+}
+
+func TestShutdownTimeoutClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	g := deprun.New(deprun.WithClock(clock), deprun.WithShutdownTimeout(time.Hour), deprun.WithLeakCheck())
+
+	stop := make(chan struct{})
+	deadline := make(chan error, 1)
+	g.AddGraceful(func() error {
+		<-stop
+		return nil
+	}, func(ctx context.Context, _ error) {
+		<-ctx.Done()
+		deadline <- ctx.Err()
+	})
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	g.AddWith(func() error { <-stuck; return nil }, func(error) {}, deprun.Name("stuck"))
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, func(error) {})
+
+	errc := make(chan error, 1)
+	go func() { errc <- g.Run() }()
+
+	// The shutdown budget only expires when the clock says so.
+	await(t, func() bool { return clock.Pending() > 0 })
+	select {
+	case err := <-errc:
+		t.Fatalf("Run returned %v before the shutdown timeout", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Hour)
+
+	select {
+	case err := <-deadline:
+		if want, have := context.DeadlineExceeded, err; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the shutdown budget did not expire with the clock")
+	}
+
+	close(stop)
+	if err := <-errc; !errors.Is(err, myError) || !errors.Is(err, deprun.ErrLeaked) {
+		t.Errorf("want %v and %v, have %v", myError, deprun.ErrLeaked, err)
+	}
+}
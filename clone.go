@@ -0,0 +1,167 @@
+package deprun
+
+import "slices"
+
+// Clone returns a copy of the group that can be run independently of g,
+// e.g. to run a template group once per test or per tenant. The copy has
+// the actors, options, health checks, hooks, finalizers and observers of g,
+// and fresh dependencies wired like those of g; clone.Ready replaces
+// g.Ready. g itself is left untouched and may be cloned again.
+//
+// Functions are shared, not copied: the execute and interrupt functions of
+// the actors must be safe to run once per clone, so state such as a stop
+// channel should be created when execute runs rather than when the actor is
+// added. Likewise the observers of g are shared by all clones. Dependencies
+// of actors outside g are kept as they are.
+//
+// Clone panics if g is running.
+func (g *Group) Clone() *Group {
+	return g.clone(func(*actor) bool { return true })
+}
+
+// Select returns a Clone of g restricted to the actors tagged with any of
+// tags, see Tags, and the actors they depend on, directly or transitively.
+// The other actors are left out, e.g. to run just the storage and API
+// actors of a group in an integration test.
+func (g *Group) Select(tags ...string) *Group {
+	actors := g.graph()
+	providers := providerIndices(actors)
+
+	keep := make(map[*Dependency]bool)
+
+	var add func(i int)
+	add = func(i int) {
+		if keep[actors[i].provides] {
+			return
+		}
+
+		keep[actors[i].provides] = true
+
+		deps, _ := actors[i].edges(providers)
+		for _, dep := range deps {
+			add(dep)
+		}
+	}
+
+	for i := range actors {
+		if slices.ContainsFunc(actors[i].tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			add(i)
+		}
+	}
+
+	return g.clone(func(a *actor) bool { return keep[a.provides] })
+}
+
+// clone implements Clone for the actors selected by keep.
+func (g *Group) clone(keep func(a *actor) bool) *Group {
+	clone := &Group{}
+
+	g.update("group cloned", func() {
+		deps := make(map[*Dependency]*Dependency)
+		for _, a := range g.actors {
+			if !keep(&a) {
+				continue
+			}
+
+			deps[a.provides] = newDependency()
+			if r := a.provides.rearm; r != nil {
+				deps[a.provides].rearm = newRearm(r.policy)
+			}
+			if a.provides.idle != nil {
+				deps[a.provides].idle = newIdle()
+				deps[a.provides].idle.clock = g.clockOrSystem()
+			}
+		}
+
+		if g.ready != nil {
+			clone.ready = newDependency()
+			deps[g.ready] = clone.ready
+		}
+
+		for _, a := range g.actors {
+			if !keep(&a) {
+				continue
+			}
+
+			a.index = len(clone.actors)
+			a.provides = deps[a.provides]
+			a.dependsOn = cloneDependencies(a.dependsOn, deps)
+			a.optional = slices.Clone(a.optional)
+			for i := range a.optional {
+				a.optional[i].d = cloneDependency(a.optional[i].d, deps)
+			}
+			a.state = new(actorState)
+			if b := a.breaker; b != nil {
+				a.breaker = &breaker{threshold: b.threshold, window: b.window, cooldown: b.cooldown}
+			}
+			clone.actors = append(clone.actors, a)
+		}
+
+		clone.hooks = append(clone.hooks, g.hooks...)
+		clone.finalizers = append(clone.finalizers, g.finalizers...)
+		clone.checks = append(clone.checks, g.checks...)
+		clone.observers = append(clone.observers, g.observers...)
+
+		clone.startLimit = g.startLimit
+		clone.startRate = g.startRate
+		clone.startRatePer = g.startRatePer
+		clone.startupTimeout = g.startupTimeout
+		clone.shutdownTimeout = g.shutdownTimeout
+		clone.maxRuntime = g.maxRuntime
+		clone.stallTimeout = g.stallTimeout
+		clone.onStall = g.onStall
+		clone.onProgress = g.onProgress
+		clone.onMissedBeat = g.onMissedBeat
+		clone.slowInterrupt = g.slowInterrupt
+		clone.onSlowInterrupt = g.onSlowInterrupt
+		clone.errorFilter = g.errorFilter
+		clone.waitAll = g.waitAll
+		clone.teardownWhen = g.teardownWhen
+		clone.runToCompletion = g.runToCompletion
+		clone.chaos = g.chaos
+		clone.orderSeed = g.orderSeed
+		clone.clock = g.clock
+		clone.leakCheck = g.leakCheck
+		clone.baseCtx = g.baseCtx
+		clone.name = g.name
+	})
+
+	return clone
+}
+
+// cloneDependencies maps deps to their clones in m. External dependencies
+// are cloned on first use, since they are resolved by the run; others
+// missing from m belong to another group and are kept.
+func cloneDependencies(deps []*Dependency, m map[*Dependency]*Dependency) []*Dependency {
+	if deps == nil {
+		return nil
+	}
+
+	result := make([]*Dependency, len(deps))
+	for i, d := range deps {
+		result[i] = cloneDependency(d, m)
+	}
+
+	return result
+}
+
+func cloneDependency(d *Dependency, m map[*Dependency]*Dependency) *Dependency {
+	if d == nil {
+		return nil
+	}
+
+	if c, ok := m[d]; ok {
+		return c
+	}
+
+	if d.source == nil {
+		return d
+	}
+
+	c := externalDependency(d.source)
+	m[d] = c
+	c.sourceDeps = cloneDependencies(d.sourceDeps, m)
+	c.quorum = d.quorum
+
+	return c
+}
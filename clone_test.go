@@ -0,0 +1,106 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestClone(t *testing.T) {
+	var (
+		template        deprun.Group
+		migrations, api atomic.Int32
+		myError         = errors.New("done")
+	)
+
+	migrated := template.AddTask(func(context.Context) error {
+		migrations.Add(1)
+
+		return nil
+	}, deprun.Name("migrate"))
+	template.AddWith(func() error {
+		api.Add(1)
+
+		return myError
+	}, nil, migrated, deprun.Name("api"))
+	ready := template.Ready()
+
+	for range 2 {
+		g := template.Clone()
+		if g.Ready() == ready {
+			t.Error("clone shares Ready with the template")
+		}
+
+		if want, have := myError, g.Run(); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+
+		if want, have := deprun.DependencyReady, g.Ready().State(); want != have {
+			t.Errorf("clone Ready: want %v, have %v", want, have)
+		}
+	}
+
+	if want, have := int32(2), migrations.Load(); want != have {
+		t.Errorf("migrations: want %d, have %d", want, have)
+	}
+
+	if want, have := int32(2), api.Load(); want != have {
+		t.Errorf("api: want %d, have %d", want, have)
+	}
+
+	if want, have := deprun.DependencyPending, migrated.State(); want != have {
+		t.Errorf("template dependency: want %v, have %v", want, have)
+	}
+
+	for _, s := range template.States() {
+		if want, have := deprun.Pending, s.State; want != have {
+			t.Errorf("template %s: want %v, have %v", s.Name, want, have)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	var g deprun.Group
+
+	nop := func(ready deprun.ReadySignal) error {
+		ready()
+		return nil
+	}
+
+	config := g.AddDepWith(nop, nil, deprun.Name("config"))
+	db := g.AddDepWith(nop, nil, config, deprun.Name("db"), deprun.Tags("storage"))
+	g.AddDepWith(nop, nil, deprun.Name("cache"), deprun.Tags("storage"))
+	g.AddWith(func() error { return nil }, nil, db, deprun.Name("api"), deprun.Tags("api"))
+	g.AddWith(func() error { return nil }, nil, deprun.Name("mailer"), deprun.Tags("mail"))
+
+	var have []string
+	for _, s := range g.Select("storage").States() {
+		have = append(have, s.Name)
+	}
+
+	if want := []string{"config", "db", "cache"}; !slices.Equal(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	subset := g.Select("api")
+	have = have[:0]
+	for _, s := range subset.States() {
+		have = append(have, s.Name)
+	}
+
+	if want := []string{"config", "db", "api"}; !slices.Equal(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if err := subset.Run(); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+
+	if want, have := 5, len(g.States()); want != have {
+		t.Errorf("template: want %d actors, have %d", want, have)
+	}
+}
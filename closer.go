@@ -0,0 +1,59 @@
+package deprun
+
+import (
+	"io"
+	"sync"
+)
+
+// AddCloser adds an actor that ties the lifetime of c to the group: it
+// blocks until interrupted, then closes c and returns the error of Close.
+// Listeners, files and clients that only need to be closed on teardown are
+// typical closers. Options, such as dependencies, are those of Add.
+func (g *Group) AddCloser(c io.Closer, opts ...ActorOption) {
+	var (
+		stop = make(chan struct{})
+		once sync.Once
+	)
+
+	g.AddWith(func() error {
+		<-stop
+
+		return c.Close()
+	}, func(error) {
+		once.Do(func() { close(stop) })
+	}, opts...)
+}
+
+// AddResource adds an actor managing a resource: it calls open, signals
+// ready once the resource is open, holds it until interrupted, then closes
+// it and returns the error of Close. An error from open is returned as is;
+// the returned Dependency then fails. This collapses the common AddDep
+// pattern of connecting, signaling ready, waiting and closing:
+//
+//	var db *sql.DB
+//	dbReady := g.AddResource(func() (io.Closer, error) {
+//		var err error
+//		db, err = openDB()
+//		return db, err
+//	})
+//	g.Add(serve, stop, dbReady)
+func (g *Group) AddResource(open func() (io.Closer, error), opts ...ActorOption) *Dependency {
+	var (
+		stop = make(chan struct{})
+		once sync.Once
+	)
+
+	return g.AddDepWith(func(ready ReadySignal) error {
+		c, err := open()
+		if err != nil {
+			return err
+		}
+
+		ready()
+		<-stop
+
+		return c.Close()
+	}, func(error) {
+		once.Do(func() { close(stop) })
+	}, opts...)
+}
@@ -0,0 +1,103 @@
+package deprun_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+type closer struct {
+	closed bool
+	err    error
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func TestAddCloser(t *testing.T) {
+	var g deprun.Group
+
+	c := &closer{}
+	g.AddCloser(c, deprun.Name("listener"))
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if !c.closed {
+		t.Error("closer not closed")
+	}
+}
+
+func TestAddCloserError(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("close failed")
+	g.AddCloser(&closer{err: myError})
+	g.Add(func() error { return nil }, nil)
+
+	report, err := g.RunReport()
+	if err != nil {
+		t.Fatalf("want no error, have %v", err)
+	}
+
+	if want, have := myError, report.Actors[0].Err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestAddResource(t *testing.T) {
+	var g deprun.Group
+
+	c := &closer{}
+	var res *closer
+	dep := g.AddResource(func() (io.Closer, error) {
+		res = c
+		return c, nil
+	}, deprun.Name("db"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		if res == nil {
+			t.Error("resource not open")
+		}
+		if c.closed {
+			t.Error("resource closed early")
+		}
+		return myError
+	}, nil, dep)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if !c.closed {
+		t.Error("resource not closed")
+	}
+}
+
+func TestAddResourceOpenError(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("connection refused")
+	dep := g.AddResource(func() (io.Closer, error) { return nil, myError })
+	g.Add(func() error {
+		t.Error("dependent started")
+		return nil
+	}, nil, dep)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := deprun.DependencyFailed, dep.State(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
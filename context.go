@@ -0,0 +1,22 @@
+package deprun
+
+import "context"
+
+// RunContext is like Run, but also tears the group down when ctx is done;
+// Run then returns context.Cause(ctx), unless an actor exited with an error
+// first. It lets a group run under an errgroup or any other code driven by a
+// context:
+//
+//	eg, ctx := errgroup.WithContext(ctx)
+//	eg.Go(func() error { return g.RunContext(ctx) })
+func (g *Group) RunContext(ctx context.Context) error {
+	h := g.Start()
+
+	select {
+	case <-ctx.Done():
+		h.Stop(context.Cause(ctx))
+	case <-h.Done():
+	}
+
+	return h.Wait()
+}
@@ -0,0 +1,85 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestRunContext(t *testing.T) {
+	var g deprun.Group
+
+	started := make(chan struct{})
+	g.Add(deprun.FuncHandler(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	myError := errors.New("parent failed")
+
+	go func() {
+		<-started
+		cancel(myError)
+	}()
+
+	if want, have := myError, g.RunContext(ctx); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestRunContextActorError(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("foobar")
+	g.Add(deprun.FuncHandler(context.Background(), func(context.Context) error {
+		return myError
+	}))
+
+	if want, have := myError, g.RunContext(context.Background()); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestContextCause(t *testing.T) {
+	var g deprun.Group
+
+	causes := make(chan error, 2)
+	g.Add(deprun.FuncHandler(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+
+		return nil
+	}))
+	g.AddTask(func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+
+		return nil
+	})
+
+	deploy := errors.New("deploy")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	go func() {
+		await(t, func() bool {
+			s := g.Snapshot()
+
+			return s.Actors[0].State == deprun.Ready && s.Actors[1].State == deprun.Running
+		})
+		cancel(deploy)
+	}()
+
+	if err := g.RunContext(ctx); err != deploy {
+		t.Errorf("want %v, have %v", deploy, err)
+	}
+
+	for range 2 {
+		if err := <-causes; !errors.Is(err, deploy) || !errors.Is(err, deprun.ErrInterrupted) {
+			t.Errorf("want %v and %v, have %v", deploy, deprun.ErrInterrupted, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestNonCritical(t *testing.T) {
+	var g deprun.Group
+	exporterDone := make(chan struct{})
+	g.AddWith(func() error {
+		defer close(exporterDone)
+		return errors.New("exporter failed")
+	}, func(error) {}, deprun.NonCritical())
+
+	myError := errors.New("main service stopped")
+	g.Add(func() error {
+		<-exporterDone
+		time.Sleep(10 * time.Millisecond) // the group keeps running
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestNonCriticalOnly(t *testing.T) {
+	var g deprun.Group
+	for range 3 {
+		g.AddWith(func() error { return errors.New("failed") }, func(error) {}, deprun.NonCritical())
+	}
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+}
+
+func TestNonCriticalProviderNeverReady(t *testing.T) {
+	var g deprun.Group
+	dep := g.AddDepWith(func(deprun.ReadySignal) error {
+		return errors.New("sidecar failed")
+	}, func(error) {}, deprun.NonCritical())
+	g.AddWith(func() error {
+		t.Error("dependent started although its dependency failed")
+		return nil
+	}, func(error) {}, dep, deprun.NonCritical())
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return myError
+	}, func(error) {})
+
+	res := make(chan error, 1)
+	go func() { res <- g.Run() }()
+	select {
+	case err := <-res:
+		if err != myError {
+			t.Errorf("want %v, have %v", myError, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("dependent kept waiting for a failed dependency")
+	}
+}
+
+func TestWaitAll(t *testing.T) {
+	g := deprun.New(deprun.WithWaitAll())
+	var finished atomic.Int32
+	dep := g.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+		finished.Add(1)
+		return nil
+	}, func(error) {})
+	for range 3 {
+		g.Add(func() error {
+			time.Sleep(time.Millisecond)
+			finished.Add(1)
+			return nil
+		}, func(error) {}, dep)
+	}
+	probe := deprun.Probe(func(context.Context) error { return nil })
+	g.AddWith(func() error { finished.Add(1); return nil }, func(error) {}, probe, deprun.NonCritical())
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+	if want, have := int32(5), finished.Load(); want != have {
+		t.Errorf("finished: want %d, have %d", want, have)
+	}
+}
+
+func TestWaitAllError(t *testing.T) {
+	g := deprun.New(deprun.WithWaitAll())
+	myError := errors.New("batch failed")
+	g.Add(func() error { return nil }, func(error) {})
+	g.Add(func() error { time.Sleep(time.Millisecond); return myError }, func(error) {})
+	stop := make(chan struct{})
+	g.Add(func() error { <-stop; return nil }, func(error) { close(stop) })
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,74 @@
+package deprun
+
+import "time"
+
+// Hop is an actor on the critical path of a startup, see CriticalPath.
+type Hop struct {
+	Name    string
+	Started time.Time
+	Ready   time.Time
+
+	// Delay is the time from the readiness of the previous hop to the start
+	// of this one, e.g. spent waiting for a slot of WithStartLimit.
+	Delay time.Duration
+}
+
+// Duration returns the time the actor took to become ready.
+func (h Hop) Duration() time.Duration {
+	return h.Ready.Sub(h.Started)
+}
+
+// CriticalPath returns the chain of dependencies that determined how long
+// the current or last run took to start: it ends with the actor that became
+// ready last and walks back, at each hop, to the dependency that became
+// ready last. Optimizing any other actor does not make the group ready
+// sooner. It returns nil if no actor became ready.
+func (g *Group) CriticalPath() []Hop {
+	actors := phased(g.graph())
+	providers := providerIndices(actors)
+
+	ready := func(i int) time.Time {
+		times, _ := actors[i].history()
+
+		return times[Ready]
+	}
+
+	last := -1
+	for i := range actors {
+		if r := ready(i); !r.IsZero() && (last < 0 || r.After(ready(last))) {
+			last = i
+		}
+	}
+
+	var path []Hop
+	for i := last; i >= 0; {
+		times, _ := actors[i].history()
+		path = append(path, Hop{
+			Name:    actors[i].String(),
+			Started: times[Running],
+			Ready:   times[Ready],
+		})
+
+		deps, _ := actors[i].edges(providers)
+
+		next := -1
+		for _, dep := range deps {
+			if r := ready(dep); !r.IsZero() && (next < 0 || r.After(ready(next))) {
+				next = dep
+			}
+		}
+
+		i = next
+	}
+
+	// Reverse the path, from the first actor to start to the last one.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	for i := 1; i < len(path); i++ {
+		path[i].Delay = path[i].Started.Sub(path[i-1].Ready)
+	}
+
+	return path
+}
@@ -0,0 +1,59 @@
+package deprun_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestCriticalPath(t *testing.T) {
+	var g deprun.Group
+
+	if path := g.CriticalPath(); path != nil {
+		t.Errorf("want no path before Run, have %v", path)
+	}
+
+	stop := make(chan struct{})
+	provider := func(d time.Duration) func(deprun.ReadySignal) error {
+		return func(ready deprun.ReadySignal) error {
+			time.Sleep(d)
+			ready()
+			<-stop
+
+			return nil
+		}
+	}
+
+	db := g.AddDepWith(provider(20*time.Millisecond), func(error) { close(stop) }, deprun.Name("db"))
+	metrics := g.AddDepWith(provider(5*time.Millisecond), nil, deprun.Name("metrics"))
+	cache := g.AddDepWith(provider(30*time.Millisecond), nil, db, deprun.Name("cache"))
+	api := g.AddDepWith(provider(0), nil, db, cache, metrics, deprun.Name("api"))
+
+	myError := errors.New("done")
+	g.AddWith(func() error { return myError }, nil, api, deprun.Name("probe"))
+	g.Run()
+
+	path := g.CriticalPath()
+
+	var names []string
+	for _, hop := range path {
+		names = append(names, hop.Name)
+	}
+
+	if want, have := "[db cache api probe]", fmt.Sprint(names); want != have {
+		t.Fatalf("want %s, have %s", want, have)
+	}
+
+	if have := path[1].Duration(); have < 30*time.Millisecond {
+		t.Errorf("cache: want at least 30ms, have %v", have)
+	}
+
+	for _, hop := range path {
+		if hop.Delay < 0 {
+			t.Errorf("%s: negative delay %v", hop.Name, hop.Delay)
+		}
+	}
+}
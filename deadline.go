@@ -0,0 +1,62 @@
+package deprun
+
+import (
+	"context"
+	"time"
+)
+
+// After returns a Dependency that becomes ready once t has passed, e.g. to
+// delay the start of an actor without a provider actor that merely sleeps.
+// Waiting stops when the group is torn down.
+func After(t time.Time) *Dependency {
+	return externalDependency(func(ctx context.Context, ready ReadySignal) error {
+		return sleepUntil(ctx, t, ready)
+	})
+}
+
+// AfterDuration returns a Dependency that becomes ready d after the group
+// starts running.
+func AfterDuration(d time.Duration) *Dependency {
+	return externalDependency(func(ctx context.Context, ready ReadySignal) error {
+		return sleepUntil(ctx, clockFrom(ctx).Now().Add(d), ready)
+	})
+}
+
+// sleepUntil calls ready once t has passed, unless ctx is done first.
+func sleepUntil(ctx context.Context, t time.Time, ready ReadySignal) error {
+	clock := clockFrom(ctx)
+	timer := clock.NewTimer(t.Sub(clock.Now()))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		ready()
+
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AtNext returns a Dependency that becomes ready at the next activation of
+// schedule after the group starts running, e.g. to start an actor in a
+// maintenance window:
+//
+//	window, err := deprun.ParseCron("0 2 * * *")
+//	...
+//	g.Add(reindex.Run, reindex.Stop, deprun.AtNext(window))
+//
+// The actor still takes part in the teardown of the group while it waits.
+// If schedule never activates, neither does the dependency.
+func AtNext(schedule Schedule) *Dependency {
+	return externalDependency(func(ctx context.Context, ready ReadySignal) error {
+		next := schedule.Next(clockFrom(ctx).Now())
+		if next.IsZero() {
+			<-ctx.Done()
+
+			return ctx.Err()
+		}
+
+		return sleepUntil(ctx, next, ready)
+	})
+}
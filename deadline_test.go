@@ -0,0 +1,91 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestAfterDuration(t *testing.T) {
+	var g deprun.Group
+
+	start := time.Now()
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, nil, deprun.AfterDuration(20*time.Millisecond))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("started after %v, want at least 20ms", elapsed)
+	}
+}
+
+func TestAfterInterrupted(t *testing.T) {
+	var g deprun.Group
+
+	var started bool
+	g.Add(func() error {
+		started = true
+		return nil
+	}, nil, deprun.After(time.Now().Add(time.Hour)))
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if started {
+		t.Error("delayed actor started")
+	}
+}
+
+func TestAtNext(t *testing.T) {
+	var g deprun.Group
+
+	start := time.Now()
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, nil, deprun.AtNext(deprun.Every(20*time.Millisecond)))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("started after %v, want at least 20ms", elapsed)
+	}
+}
+
+func TestAtNextNever(t *testing.T) {
+	var g deprun.Group
+
+	never, err := deprun.ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var started bool
+	g.Add(func() error {
+		started = true
+		return nil
+	}, nil, deprun.AtNext(never))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if started {
+		t.Error("actor started on a schedule that never activates")
+	}
+}
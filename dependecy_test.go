@@ -1,7 +1,9 @@
 package deprun_test
 
 import (
+	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -163,3 +165,134 @@ func TestDependencyFailure(t *testing.T) {
 		t.Fatalf("group.Run deadlocked after dependency failure")
 	}
 }
+
+func TestDependencyState(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("no connection")
+	failed := g.AddDepWith(func(deprun.ReadySignal) error { return myError }, func(error) {}, deprun.NonCritical())
+
+	stop := make(chan struct{})
+	ready := g.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+
+	interrupted := g.AddDep(func(deprun.ReadySignal) error {
+		<-stop
+		return nil
+	}, func(error) {})
+
+	if want, have := deprun.DependencyPending, ready.State(); want != have {
+		t.Errorf("before run: want %v, have %v", want, have)
+	}
+
+	g.Add(func() error {
+		state, err := failed.Wait(context.Background())
+		if want, have := deprun.DependencyFailed, state; want != have {
+			t.Errorf("failed: want %v, have %v", want, have)
+		}
+		if want, have := myError, err; want != have {
+			t.Errorf("failed: want %v, have %v", want, have)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if state, err := interrupted.Wait(ctx); state != deprun.DependencyPending || err != context.DeadlineExceeded {
+			t.Errorf("pending: want %v, %v, have %v, %v", deprun.DependencyPending, context.DeadlineExceeded, state, err)
+		}
+
+		return errors.New("done")
+	}, func(error) {}, ready)
+
+	g.Run()
+
+	for _, tc := range []struct {
+		dep  *deprun.Dependency
+		want deprun.DependencyState
+		err  error
+	}{
+		{failed, deprun.DependencyFailed, myError},
+		{ready, deprun.DependencyReady, nil},
+		{interrupted, deprun.DependencyInterrupted, nil},
+	} {
+		select {
+		case <-tc.dep.Done():
+		default:
+			t.Errorf("%v: not done", tc.want)
+		}
+
+		if want, have := tc.want, tc.dep.State(); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+
+		if want, have := tc.err, tc.dep.Err(); want != have {
+			t.Errorf("%v: want %v, have %v", tc.want, want, have)
+		}
+	}
+}
+
+func TestDependsOn(t *testing.T) {
+	var g deprun.Group
+
+	var deps []*deprun.Dependency
+	var ready atomic.Int32
+	stop := make(chan struct{})
+	for range 3 {
+		deps = append(deps, g.AddDep(func(r deprun.ReadySignal) error {
+			ready.Add(1)
+			r()
+			<-stop
+			return nil
+		}, func(error) {}))
+	}
+
+	myError := errors.New("done")
+	g.AddWith(func() error {
+		if want, have := int32(3), ready.Load(); want != have {
+			t.Errorf("want %d dependencies ready, have %d", want, have)
+		}
+		return myError
+	}, func(error) { close(stop) }, deprun.DependsOn(deps...), deprun.Name("api"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestDependencySpread(t *testing.T) {
+	var (
+		g     deprun.Group
+		deps  []*deprun.Dependency
+		ready atomic.Int32
+		stop  = make(chan struct{})
+	)
+
+	for range 2 {
+		deps = append(deps, g.AddDep(func(r deprun.ReadySignal) error {
+			ready.Add(1)
+			r()
+			<-stop
+			return nil
+		}, func(error) {}))
+	}
+	deps = append(deps, g.AddDep(func(r deprun.ReadySignal) error {
+		ready.Add(1)
+		r()
+		<-stop
+		return nil
+	}, func(error) {}, deps...))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		if want, have := int32(3), ready.Load(); want != have {
+			t.Errorf("want %d dependencies ready, have %d", want, have)
+		}
+		return myError
+	}, func(error) { close(stop) }, deps...)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
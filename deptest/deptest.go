@@ -0,0 +1,175 @@
+// Package deptest provides assertions for tests of deprun groups, so that
+// tests need not track the lifecycle of actors with channels themselves:
+//
+//	r := deptest.NewRecorder()
+//	g := deprun.New(deprun.WithObserver(r))
+//	...
+//	err := deptest.RunWithin(t, g, time.Second)
+//	r.ReadyBefore(t, "db", "api")
+//	r.AllInterrupted(t)
+//
+// Actors are identified by name, see deprun.Name.
+package deptest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+// EventKind is the kind of an Event.
+type EventKind int
+
+// Event kinds.
+const (
+	Started EventKind = iota
+	Ready
+	Exited
+	Interrupted
+)
+
+var eventKinds = [...]string{
+	Started:     "started",
+	Ready:       "ready",
+	Exited:      "exited",
+	Interrupted: "interrupted",
+}
+
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventKinds) {
+		return "unknown"
+	}
+
+	return eventKinds[k]
+}
+
+// Event is a step in the lifecycle of an actor, see Recorder.
+type Event struct {
+	Kind  EventKind
+	Actor string
+	Err   error // the error of Exited and Interrupted events
+}
+
+// Recorder is a deprun.Observer recording the lifecycle of the actors of a
+// group, in order, to make assertions on it. It is reset when the group
+// starts running.
+type Recorder struct {
+	deprun.NopObserver
+
+	mu     sync.Mutex
+	actors []string
+	events []Event
+}
+
+// NewRecorder returns a Recorder, to be registered with deprun.WithObserver.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) OnGroupStart(actors []deprun.ActorInfo, _ deprun.Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.actors, r.events = nil, nil
+	for _, a := range actors {
+		r.actors = append(r.actors, a.Name)
+	}
+}
+
+func (r *Recorder) OnActorStart(actor deprun.ActorInfo) {
+	r.record(Event{Kind: Started, Actor: actor.Name})
+}
+
+func (r *Recorder) OnActorReady(actor deprun.ActorInfo) {
+	r.record(Event{Kind: Ready, Actor: actor.Name})
+}
+
+func (r *Recorder) OnActorExit(actor deprun.ActorInfo, err error) {
+	r.record(Event{Kind: Exited, Actor: actor.Name, Err: err})
+}
+
+func (r *Recorder) OnInterrupt(actor deprun.ActorInfo, err error) {
+	r.record(Event{Kind: Interrupted, Actor: actor.Name, Err: err})
+}
+
+func (r *Recorder) record(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, e)
+}
+
+// Events returns the events recorded so far, in order.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.events)
+}
+
+// index returns the position of the first event of kind for actor, or -1.
+func (r *Recorder) index(kind EventKind, actor string) int {
+	return slices.IndexFunc(r.Events(), func(e Event) bool {
+		return e.Kind == kind && e.Actor == actor
+	})
+}
+
+// ReadyBefore fails t unless actor a became ready before actor b started.
+func (r *Recorder) ReadyBefore(t testing.TB, a, b string) {
+	t.Helper()
+
+	ready, started := r.index(Ready, a), r.index(Started, b)
+	switch {
+	case ready < 0:
+		t.Errorf("deptest: %s never became ready", a)
+	case started < 0:
+		t.Errorf("deptest: %s never started", b)
+	case started < ready:
+		t.Errorf("deptest: %s started before %s became ready", b, a)
+	}
+}
+
+// AllInterrupted fails t unless the interrupt function of every actor of
+// the group was called, listing those whose was not.
+func (r *Recorder) AllInterrupted(t testing.TB) {
+	t.Helper()
+
+	r.mu.Lock()
+	actors := slices.Clone(r.actors)
+	r.mu.Unlock()
+
+	var missing []string
+	for _, a := range actors {
+		if r.index(Interrupted, a) < 0 {
+			missing = append(missing, a)
+		}
+	}
+
+	if len(missing) > 0 {
+		t.Errorf("deptest: not interrupted: %v", missing)
+	}
+}
+
+// RunWithin runs g and returns the error of Run, or fails t at once unless
+// Run returns within d. Run is then left running.
+func RunWithin(t testing.TB, g *deprun.Group, d time.Duration) error {
+	t.Helper()
+
+	errc := make(chan error, 1)
+	go func() { errc <- g.Run() }()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-timer.C:
+		t.Fatalf("deptest: Run did not return within %v:\n%s", d, g)
+
+		return nil
+	}
+}
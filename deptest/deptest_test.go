@@ -0,0 +1,102 @@
+package deptest_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+	"github.com/istovpets/deprun/deptest"
+)
+
+// fakeT records the failures of the assertions under test.
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func TestRecorder(t *testing.T) {
+	r := deptest.NewRecorder()
+	g := deprun.New(deprun.WithObserver(r))
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	g.AddWith(func() error {
+		return errors.New("done")
+	}, func(error) {}, db, deprun.Name("api"))
+
+	if err := deptest.RunWithin(t, g, time.Second); err == nil {
+		t.Fatal("want error, have none")
+	}
+
+	r.ReadyBefore(t, "db", "api")
+	r.AllInterrupted(t)
+
+	f := &fakeT{}
+	r.ReadyBefore(f, "api", "db")
+	r.ReadyBefore(f, "db", "cache")
+	if want, have := []string{
+		"deptest: db started before api became ready",
+		"deptest: cache never started",
+	}, f.errors; fmt.Sprint(want) != fmt.Sprint(have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestAllInterrupted(t *testing.T) {
+	r := deptest.NewRecorder()
+	r.OnGroupStart([]deprun.ActorInfo{{Name: "job"}, {Name: "api"}}, nil)
+	r.OnInterrupt(deprun.ActorInfo{Name: "job"}, nil)
+
+	f := &fakeT{}
+	r.AllInterrupted(f)
+	if want, have := []string{"deptest: not interrupted: [api]"}, f.errors; fmt.Sprint(want) != fmt.Sprint(have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestRunWithin(t *testing.T) {
+	g := deprun.New()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	g.AddWith(func() error {
+		<-stop
+		return nil
+	}, func(error) {}, deprun.Name("stuck"))
+
+	f := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = deptest.RunWithin(f, g, 10*time.Millisecond)
+	}()
+	<-done
+
+	if want, have := 1, len(f.errors); want != have {
+		t.Fatalf("want %d failure, have %d", want, have)
+	}
+
+	if want, have := "  stuck: ", f.errors[0]; !strings.Contains(have, want) {
+		t.Errorf("want %q in %q", want, have)
+	}
+}
@@ -0,0 +1,106 @@
+package deprun
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// WithDeterministicOrder starts the actors one at a time, in an order drawn
+// from seed among the orders that respect their dependencies, so that
+// ordering-dependent tests can be reproduced exactly: an actor starts once
+// the previous one has become ready, or has returned. Readiness is thus
+// delivered in the same order on every run with the same seed and
+// registrations. An actor that never becomes ready holds back the actors
+// after it, as with WithStartLimit(1). Lazy and disabled actors start
+// outside of the order. It is meant for tests.
+func WithDeterministicOrder(seed uint64) Option {
+	return func(g *Group) {
+		g.orderSeed = &seed
+	}
+}
+
+// sequence gives each actor its turn in the deterministic order, see
+// WithDeterministicOrder.
+func (g *Group) sequence(actors []actor) {
+	if g.orderSeed == nil {
+		return
+	}
+
+	var (
+		r          = rand.New(rand.NewPCG(*g.orderSeed, *g.orderSeed))
+		providers  = providerIndices(actors)
+		pending    = make([]int, len(actors)) // dependencies not yet ordered
+		dependents = make([][]int, len(actors))
+		candidates []int
+		order      []int
+	)
+
+	sequenced := func(a *actor) bool {
+		return !a.hidden && !a.lazy && !a.disabled
+	}
+
+	for i := range actors {
+		if !sequenced(&actors[i]) {
+			continue
+		}
+
+		deps, _ := actors[i].edges(providers)
+		for _, dep := range deps {
+			if dep != i && sequenced(&actors[dep]) {
+				pending[i]++
+				dependents[dep] = append(dependents[dep], i)
+			}
+		}
+
+		if pending[i] == 0 {
+			candidates = append(candidates, i)
+		}
+	}
+
+	for len(candidates) > 0 {
+		k := r.IntN(len(candidates))
+		i := candidates[k]
+		candidates = append(candidates[:k], candidates[k+1:]...)
+		order = append(order, i)
+
+		for _, d := range dependents[i] {
+			if pending[d]--; pending[d] == 0 {
+				candidates = append(candidates, d)
+			}
+		}
+	}
+
+	// Actors in a dependency cycle never get a turn; they wait for their
+	// dependencies anyway.
+	turn := make(chan struct{})
+	close(turn)
+
+	for _, i := range order {
+		next := make(chan struct{})
+		actors[i].turn = turn
+		actors[i].passTurn = sync.OnceFunc(func() { close(next) })
+		turn = next
+	}
+}
+
+// awaitTurn blocks until it is the turn of a to start, and reports false
+// if stop is closed first.
+func (a *actor) awaitTurn(stop <-chan struct{}) bool {
+	if a.turn == nil {
+		return true
+	}
+
+	select {
+	case <-a.turn:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// endTurn lets the next actor in the deterministic order start.
+func (a *actor) endTurn() {
+	if a.passTurn != nil {
+		a.passTurn()
+	}
+}
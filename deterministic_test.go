@@ -0,0 +1,89 @@
+package deprun_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+// startOrder runs a group started in the order drawn from seed and returns
+// the order in which its actors started.
+func startOrder(t *testing.T, seed uint64) []string {
+	t.Helper()
+
+	g := deprun.New(deprun.WithDeterministicOrder(seed))
+
+	var (
+		mu    sync.Mutex
+		order []string
+		all   = make(chan struct{}) // closed once every actor started
+	)
+
+	start := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if order = append(order, name); len(order) == 6 {
+			close(all)
+		}
+	}
+
+	provider := func(name string, opts ...deprun.ActorOption) *deprun.Dependency {
+		stop := make(chan struct{})
+
+		return g.AddDepWith(func(ready deprun.ReadySignal) error {
+			start(name)
+			ready()
+			<-stop
+
+			return nil
+		}, func(error) { close(stop) }, append(opts, deprun.Name(name))...)
+	}
+
+	db := provider("db")
+	cache := provider("cache")
+	provider("queue")
+	api := provider("api", deprun.DependsOn(db, cache))
+	provider("worker", deprun.DependsOn(db))
+
+	// The check is ready as soon as it starts, letting the next actor start.
+	g.AddWith(func() error {
+		start("check")
+		<-all
+
+		return nil
+	}, func(error) {}, deprun.DependsOn(api), deprun.Name("check"))
+
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dep := range [][2]string{{"db", "api"}, {"cache", "api"}, {"db", "worker"}, {"api", "check"}} {
+		if slices.Index(order, dep[0]) > slices.Index(order, dep[1]) {
+			t.Errorf("seed %d: %s started before %s: %v", seed, dep[1], dep[0], order)
+		}
+	}
+
+	return order
+}
+
+func TestWithDeterministicOrder(t *testing.T) {
+	seen := make(map[string]bool)
+	for seed := range uint64(10) {
+		want := startOrder(t, seed)
+		for range 5 {
+			if have := startOrder(t, seed); !slices.Equal(want, have) {
+				t.Errorf("seed %d: want %v, have %v", seed, want, have)
+			}
+		}
+
+		seen[fmt.Sprint(want)] = true
+	}
+
+	if len(seen) < 2 {
+		t.Errorf("want different orders for different seeds, have %v", seen)
+	}
+}
@@ -0,0 +1,170 @@
+package deprun
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime/pprof"
+	"slices"
+	"strings"
+)
+
+// Dump writes a diagnostic report of the group to w: the status of the
+// group, the state of every actor and, for actors waiting for their
+// dependencies, the actors or external dependencies they are still waiting
+// for. Dump is safe to call concurrently with Run; it is most useful when
+// startup hangs.
+func (g *Group) Dump(w io.Writer) error {
+	return g.dump(w, false)
+}
+
+// DumpStacks is like Dump, and also writes the goroutine stacks of every
+// actor, as identified by their profiler labels.
+func (g *Group) DumpStacks(w io.Writer) error {
+	return g.dump(w, true)
+}
+
+// DumpHandler returns an actor, i.e. an execute and interrupt func, that
+// writes DumpStacks of g to w whenever the process receives one of the
+// provided signals, e.g. syscall.SIGUSR1 or syscall.SIGQUIT. It terminates
+// when interrupted, or with the error of a failed write to w. DumpHandler
+// panics if no signal is provided, since that would relay every signal,
+// SIGINT and SIGTERM included.
+func DumpHandler(g *Group, w io.Writer, signals ...os.Signal) (execute func() error, interrupt func(error)) {
+	if len(signals) == 0 {
+		panic("deprun: DumpHandler called without signals")
+	}
+
+	stop := make(chan struct{})
+	return func() error {
+			sigc := make(chan os.Signal, 1)
+			signal.Notify(sigc, signals...)
+			defer signal.Stop(sigc)
+			for {
+				select {
+				case <-sigc:
+					if err := g.DumpStacks(w); err != nil {
+						return err
+					}
+				case <-stop:
+					return nil
+				}
+			}
+		}, func(error) {
+			close(stop)
+		}
+}
+
+func (g *Group) dump(w io.Writer, stacks bool) error {
+	actors := g.graph()
+	if run := g.run.Load(); run != nil {
+		actors = *run
+	}
+
+	providers := providerNames(actors)
+
+	var profile []string
+	if stacks {
+		profile = goroutineProfile()
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "deprun: group %s\n", groupStatuses[g.status.Load()])
+
+	for i := range actors {
+		a := &actors[i]
+		if a.hidden {
+			continue
+		}
+
+		state := a.currentState()
+		fmt.Fprintf(bw, "%s: %s", a.qualified(), state)
+
+		if state == WaitingDeps {
+			if pending := a.pendingDeps(providers); len(pending) > 0 {
+				fmt.Fprintf(bw, " (%s)", strings.Join(pending, ", "))
+			}
+		}
+
+		fmt.Fprintln(bw)
+
+		if stacks {
+			label := fmt.Sprintf("%q:%q", "deprun.actor", a.String())
+			for _, record := range profile {
+				if strings.Contains(record, label) {
+					fmt.Fprintf(bw, "\t%s\n", strings.ReplaceAll(strings.TrimSpace(record), "\n", "\n\t"))
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// providerNames maps the dependencies provided by actors to the names of the
+// providing actors.
+func providerNames(actors []actor) map[*Dependency]string {
+	providers := make(map[*Dependency]string)
+	for i := range actors {
+		if !actors[i].hidden {
+			providers[actors[i].provides] = actors[i].String()
+		}
+	}
+
+	return providers
+}
+
+// pendingDeps returns the names of what a is still waiting for.
+func (a *actor) pendingDeps(providers map[*Dependency]string) []string {
+	var pending []string
+	for _, d := range a.deps.remaining(a.dependsOn) {
+		pending = appendPending(pending, d, providers)
+	}
+
+	return pending
+}
+
+// appendPending appends the names of what d is still waiting for: the actor
+// providing d, the pending dependencies of an external dependency, or
+// "external dependency".
+func appendPending(pending []string, d *Dependency, providers map[*Dependency]string) []string {
+	if d == nil || d.resolved() {
+		return pending
+	}
+
+	if name, ok := providers[d]; ok {
+		return appendUnique(pending, name)
+	}
+
+	n := len(pending)
+	for _, dep := range d.sourceDeps {
+		pending = appendPending(pending, dep, providers)
+	}
+
+	if len(pending) == n {
+		pending = appendUnique(pending, "external dependency")
+	}
+
+	return pending
+}
+
+func appendUnique(s []string, v string) []string {
+	if slices.Contains(s, v) {
+		return s
+	}
+
+	return append(s, v)
+}
+
+// goroutineProfile returns the records of the goroutine profile.
+func goroutineProfile() []string {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return nil
+	}
+
+	return strings.Split(buf.String(), "\n\n")
+}
@@ -0,0 +1,80 @@
+package deprun_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestDump(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	cacheStarted := make(chan struct{})
+	cache := g.AddDepWith(func(deprun.ReadySignal) error {
+		close(cacheStarted)
+		<-stop
+		return nil
+	}, func(error) {}, deprun.Name("cache"))
+
+	probe := deprun.Probe(func(context.Context) error { return errors.New("down") })
+	g.AddWith(func() error { return nil }, func(error) {}, cache, db, probe, deprun.Name("worker"))
+	g.Phase(1).AddWith(func() error { return nil }, func(error) {}, deprun.Name("late"))
+
+	var dump, stacks bytes.Buffer
+	myError := errors.New("boom")
+	g.AddWith(func() error {
+		<-cacheStarted
+		g.Dump(&dump)
+		g.DumpStacks(&stacks)
+		return myError
+	}, func(error) {}, db, deprun.Name("api"))
+
+	var before bytes.Buffer
+	g.Dump(&before)
+	if want := "db: pending\n"; !strings.Contains(before.String(), want) {
+		t.Errorf("before run: missing %q in:\n%s", want, before.String())
+	}
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	for _, want := range []string{
+		"deprun: group running\n",
+		"db: ready\n",
+		"cache: running\n",
+		"worker: waiting for dependencies (cache, external dependency)\n",
+		"late: waiting for dependencies (cache, worker)\n",
+		"api: ready\n",
+	} {
+		if !strings.Contains(dump.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, dump.String())
+		}
+	}
+
+	if want := "deprun_test.TestDump"; !strings.Contains(stacks.String(), want) {
+		t.Errorf("missing stack frame %q in:\n%s", want, stacks.String())
+	}
+}
+
+func TestDumpHandlerNoSignals(t *testing.T) {
+	defer func() {
+		if want, have := "deprun: DumpHandler called without signals", recover(); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	}()
+
+	var g deprun.Group
+	deprun.DumpHandler(&g, &bytes.Buffer{})
+}
@@ -0,0 +1,23 @@
+package deprun
+
+// Enabled makes an actor conditional, e.g. on a feature flag or on the
+// configuration of an optional subsystem: enabled is called when the group,
+// or a Clone of it, runs, and if it returns false the actor is skipped for
+// that run.
+// A skipped actor is neither executed nor interrupted, its state is
+// Disabled, and the Dependency it provides is ready at once, so that its
+// dependents start without it. Dependents that cannot do without it should
+// be gated by the same condition.
+func Enabled(enabled func() bool) ActorOption {
+	return actorOption(func(a *actor) {
+		a.enabled = enabled
+	})
+}
+
+// disable marks the actors whose Enabled condition is false.
+func disable(actors []actor) {
+	for i := range actors {
+		a := &actors[i]
+		a.disabled = a.enabled != nil && !a.enabled()
+	}
+}
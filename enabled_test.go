@@ -0,0 +1,72 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestEnabled(t *testing.T) {
+	var (
+		g       deprun.Group
+		enabled bool
+		ran     bool
+	)
+
+	cache := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ran = true
+		ready()
+
+		return nil
+	}, func(error) {
+		t.Error("disabled actor interrupted")
+	}, deprun.Name("cache"), deprun.Enabled(func() bool { return enabled }))
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, func(error) {}, cache)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if ran {
+		t.Error("disabled actor ran")
+	}
+
+	if want, have := deprun.Disabled, g.States()[0].State; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := deprun.DependencyReady, cache.State(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestEnabledPerRun(t *testing.T) {
+	var (
+		g       deprun.Group
+		enabled bool
+		runs    int
+	)
+
+	g.AddWith(func() error {
+		runs++
+		return nil
+	}, func(error) {}, deprun.Enabled(func() bool { return enabled }))
+
+	g.Add(func() error { return nil }, func(error) {})
+
+	if err := g.Clone().Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	enabled = true
+	if err := g.Clone().Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 1, runs; want != have {
+		t.Errorf("want %d runs, have %d", want, have)
+	}
+}
@@ -0,0 +1,51 @@
+package deprun
+
+import "errors"
+
+// IgnoreErrors returns an error mapping, for use with WithErrorFilter or
+// MapError, that treats errors matching any of targets, per errors.Is, as a
+// clean exit (nil). Other errors pass through unchanged.
+//
+//	deprun.WithErrorFilter(deprun.IgnoreErrors(http.ErrServerClosed, context.Canceled))
+func IgnoreErrors(targets ...error) func(error) error {
+	return func(err error) error {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return nil
+			}
+		}
+
+		return err
+	}
+}
+
+// WithErrorFilter maps the error returned by every actor before it is
+// passed to the interrupt functions and returned by Run. Returning nil turns
+// the exit into a clean one. The filter is applied after the actor's own
+// MapError, if any.
+func WithErrorFilter(filter func(error) error) Option {
+	return func(g *Group) {
+		g.errorFilter = filter
+	}
+}
+
+// MapError maps the error returned by an actor before it propagates, like
+// WithErrorFilter but for a single actor.
+func MapError(mapping func(error) error) ActorOption {
+	return actorOption(func(a *actor) {
+		a.mapError = mapping
+	})
+}
+
+// filterError applies the actor's and the group's error mappings to err.
+func (g *Group) filterError(a *actor, err error) error {
+	if a.mapError != nil {
+		err = a.mapError(err)
+	}
+
+	if g.errorFilter != nil {
+		err = g.errorFilter(err)
+	}
+
+	return err
+}
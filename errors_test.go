@@ -0,0 +1,49 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestWithErrorFilter(t *testing.T) {
+	g := deprun.New(deprun.WithErrorFilter(deprun.IgnoreErrors(http.ErrServerClosed, context.Canceled)))
+	var interruptErr error
+	g.Add(func() error {
+		return http.ErrServerClosed
+	}, func(err error) { interruptErr = err })
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+	if interruptErr != nil {
+		t.Errorf("interrupt: want nil, have %v", interruptErr)
+	}
+}
+
+func TestMapError(t *testing.T) {
+	myError := errors.New("mapped")
+	var g deprun.Group
+	g.AddWith(func() error {
+		return context.Canceled
+	}, func(error) {}, deprun.MapError(func(err error) error {
+		if errors.Is(err, context.Canceled) {
+			return myError
+		}
+		return err
+	}))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestIgnoreErrorsPassThrough(t *testing.T) {
+	myError := errors.New("real failure")
+	if want, have := myError, deprun.IgnoreErrors(context.Canceled)(myError); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,171 @@
+package deprun
+
+import (
+	"sync"
+	"time"
+)
+
+// EventKind identifies the kind of an Event.
+type EventKind int
+
+// Kinds of events, see Group.Events.
+const (
+	GroupStarted     EventKind = iota // Run started
+	ActorStarted                      // an actor started, see Observer.OnActorStart
+	ActorReady                        // an actor became ready
+	ActorExited                       // an actor returned
+	TeardownBegan                     // the group is being torn down
+	ActorInterrupted                  // an actor was interrupted
+	GroupDone                         // Run returned
+)
+
+var eventKinds = [...]string{
+	GroupStarted:     "group started",
+	ActorStarted:     "actor started",
+	ActorReady:       "actor ready",
+	ActorExited:      "actor exited",
+	TeardownBegan:    "teardown began",
+	ActorInterrupted: "actor interrupted",
+	GroupDone:        "group done",
+}
+
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventKinds) {
+		return "unknown event"
+	}
+
+	return eventKinds[k]
+}
+
+// Event is a lifecycle event of a group, see Group.Events.
+type Event struct {
+	Kind  EventKind
+	Time  time.Time
+	Actor string // the actor name; empty for group events
+	Index int    // the registration index of the actor, see ActorInfo
+	Err   error  // the error of ActorExited, ActorInterrupted, TeardownBegan and GroupDone events
+}
+
+// Events returns a channel streaming the lifecycle events of the run of
+// the group, in order. Events are queued without bound, so that a slow
+// consumer never stalls the group; the channel is closed after the
+// GroupDone event. Each call returns a new channel receiving all events.
+//
+// Events must be called before Run. Use an Observer to handle events
+// synchronously instead.
+func (g *Group) Events() <-chan Event {
+	s := &eventStream{
+		out:  make(chan Event),
+		wake: make(chan struct{}, 1),
+	}
+	g.update("Events called", func() {
+		g.observers = append(g.observers, s)
+	})
+
+	return s.out
+}
+
+// eventStream is the Observer feeding a channel returned by Events.
+type eventStream struct {
+	out  chan Event
+	wake chan struct{}
+
+	mu          sync.Mutex
+	clock       Clock
+	queue       []Event
+	started     bool
+	done        bool
+	tearingDown bool
+}
+
+func (s *eventStream) push(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started || s.done {
+		return
+	}
+
+	e.Time = s.clock.Now()
+	s.queue = append(s.queue, e)
+
+	if e.Kind == GroupDone {
+		s.done = true
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends queued events to the channel until the GroupDone event.
+func (s *eventStream) deliver() {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.done {
+			s.mu.Unlock()
+			<-s.wake
+			s.mu.Lock()
+		}
+
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			close(s.out)
+
+			return
+		}
+
+		e := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		s.out <- e
+	}
+}
+
+func (s *eventStream) OnGroupStart(_ []ActorInfo, clock Clock) {
+	s.mu.Lock()
+	first := !s.started
+	s.started = true
+	if first {
+		s.clock = clock
+	}
+	s.mu.Unlock()
+
+	if !first {
+		return
+	}
+
+	go s.deliver()
+	s.push(Event{Kind: GroupStarted})
+}
+
+func (s *eventStream) OnActorStart(actor ActorInfo) {
+	s.push(Event{Kind: ActorStarted, Actor: actor.Name, Index: actor.Index})
+}
+
+func (s *eventStream) OnActorReady(actor ActorInfo) {
+	s.push(Event{Kind: ActorReady, Actor: actor.Name, Index: actor.Index})
+}
+
+func (s *eventStream) OnActorExit(actor ActorInfo, err error) {
+	s.push(Event{Kind: ActorExited, Actor: actor.Name, Index: actor.Index, Err: err})
+}
+
+func (s *eventStream) OnInterrupt(actor ActorInfo, err error) {
+	s.mu.Lock()
+	first := !s.tearingDown
+	s.tearingDown = true
+	s.mu.Unlock()
+
+	if first {
+		s.push(Event{Kind: TeardownBegan, Err: err})
+	}
+
+	s.push(Event{Kind: ActorInterrupted, Actor: actor.Name, Index: actor.Index, Err: err})
+}
+
+func (s *eventStream) OnGroupDone(err error) {
+	s.push(Event{Kind: GroupDone, Err: err})
+}
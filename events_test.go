@@ -0,0 +1,120 @@
+package deprun_test
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestEvents(t *testing.T) {
+	var g deprun.Group
+	events := g.Events()
+
+	myError := errors.New("boom")
+	g.AddWith(func() error { return myError }, func(error) {}, deprun.Name("api"))
+
+	collected := make(chan []string)
+	go func() {
+		var have []string
+		for e := range events {
+			if e.Time.IsZero() {
+				t.Errorf("%v: zero time", e.Kind)
+			}
+			have = append(have, fmt.Sprintf("%v %s %v", e.Kind, e.Actor, e.Err))
+		}
+		collected <- have
+	}()
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	want := []string{
+		"group started  <nil>",
+		"actor started api <nil>",
+		"actor ready api <nil>",
+		"actor exited api boom",
+		"teardown began  boom",
+		"actor interrupted api boom",
+		"group done  boom",
+	}
+	if have := <-collected; !slices.Equal(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestEventsSlowConsumer(t *testing.T) {
+	g := deprun.New(deprun.WithWaitAll())
+	events := g.Events()
+	for range 100 {
+		g.Add(func() error { return nil }, func(error) {})
+	}
+
+	// Run must not wait for the consumer.
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	for range events {
+		n++
+	}
+
+	if want, have := 1+100*3+1+100+1, n; want != have {
+		t.Errorf("want %d events, have %d", want, have)
+	}
+}
+
+func TestEventsIndex(t *testing.T) {
+	g := deprun.New(deprun.WithWaitAll())
+	events := g.Events()
+
+	for range 2 {
+		g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("worker"))
+	}
+
+	done := make(chan map[int]int)
+	go func() {
+		exits := make(map[int]int)
+		for e := range events {
+			if e.Kind == deprun.ActorExited {
+				exits[e.Index]++
+			}
+		}
+		done <- exits
+	}()
+
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := map[int]int{0: 1, 1: 1}, <-done; !maps.Equal(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestEventsBeforeRunError(t *testing.T) {
+	var g deprun.Group
+	events := g.Events()
+
+	myError := errors.New("config")
+	g.BeforeRun(func() error { return myError })
+	g.Add(func() error { return nil }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	var have []string
+	for e := range events {
+		have = append(have, fmt.Sprintf("%v %v", e.Kind, e.Err))
+	}
+
+	if want := []string{"group started <nil>", "group done config"}; !slices.Equal(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
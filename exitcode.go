@@ -0,0 +1,87 @@
+package deprun
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// ExitCoder is implemented by errors carrying a process exit code, such as
+// *exec.ExitError.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+var exitCodes struct {
+	sync.Mutex
+	mappings []exitCodeMapping
+}
+
+type exitCodeMapping struct {
+	target error
+	code   int
+}
+
+// RegisterExitCode makes ExitCode return code for errors matching target,
+// per errors.Is. Mappings are tried in registration order, before any other
+// rule of ExitCode. It is typically called from init functions.
+func RegisterExitCode(target error, code int) {
+	exitCodes.Lock()
+	defer exitCodes.Unlock()
+
+	exitCodes.mappings = append(exitCodes.mappings, exitCodeMapping{target, code})
+}
+
+// ExitCode returns the process exit code for err, typically the error
+// returned by Run:
+//
+//   - 0 for nil, a clean exit;
+//   - the code of the first mapping registered with RegisterExitCode that
+//     matches err;
+//   - the code of an ExitCoder in the chain of err, if it is not negative;
+//   - 128 plus the signal number for a SignalError, as shells do;
+//   - 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	exitCodes.Lock()
+	mappings := exitCodes.mappings
+	exitCodes.Unlock()
+
+	for _, m := range mappings {
+		if errors.Is(err, m.target) {
+			return m.code
+		}
+	}
+
+	var coder ExitCoder
+	if errors.As(err, &coder) && coder.ExitCode() >= 0 {
+		return coder.ExitCode()
+	}
+
+	if sig, ok := signalOf(err).(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+
+	return 1
+}
+
+// signalOf returns the signal of a SignalError in the chain of err, or nil.
+func signalOf(err error) os.Signal {
+	var p *SignalError
+	if errors.As(err, &p) && p != nil {
+		return p.Signal
+	}
+
+	// SignalError.As reports a match without setting the target, so v is
+	// only set if a SignalError value is in the chain.
+	var v SignalError
+	if errors.As(err, &v) {
+		return v.Signal
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package deprun_test
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+var errExitCodeTest = errors.New("config invalid")
+
+func init() {
+	deprun.RegisterExitCode(errExitCodeTest, 78)
+}
+
+type coded int
+
+func (c coded) Error() string { return "coded" }
+func (c coded) ExitCode() int { return int(c) }
+
+func TestExitCode(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{errors.New("foobar"), 1},
+		{fmt.Errorf("wrapped: %w", errExitCodeTest), 78},
+		{fmt.Errorf("wrapped: %w", coded(3)), 3},
+		{coded(-1), 1},
+		{&deprun.SignalError{Signal: syscall.SIGINT}, 128 + int(syscall.SIGINT)},
+		{fmt.Errorf("wrapped: %w", deprun.SignalError{Signal: syscall.SIGTERM}), 128 + int(syscall.SIGTERM)},
+		{deprun.SignalError{}, 1},
+	} {
+		if want, have := tc.want, deprun.ExitCode(tc.err); want != have {
+			t.Errorf("%v: want %d, have %d", tc.err, want, have)
+		}
+	}
+}
+
+func TestExitCodeCommand(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not found")
+	}
+
+	err = exec.Command(sh, "-c", "exit 7").Run()
+	if want, have := 7, deprun.ExitCode(err); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
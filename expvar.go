@@ -0,0 +1,144 @@
+package deprun
+
+import (
+	"expvar"
+	"sync"
+)
+
+// WithExpvar publishes the state of the group under name with package
+// expvar, so that it shows up in /debug/vars. The published value is a JSON
+// object holding the status of the group ("idle", "running", "stopping" or
+// "stopped"), the name, state and error of every actor in registration
+// order, with the states of ActorState, how many actors are ready and the
+// last error returned by an actor or by Run.
+//
+// The value follows the runs the observer is notified of, so a Clone of the
+// group publishes its runs under the same name.
+//
+// Like expvar.Publish, WithExpvar panics if name is already in use.
+func WithExpvar(name string) Option {
+	return func(g *Group) {
+		o := &expvarObserver{}
+		expvar.Publish(name, expvar.Func(o.value))
+		g.observers = append(g.observers, o)
+	}
+}
+
+// expvarState is the value published by WithExpvar.
+type expvarState struct {
+	Status    string        `json:"status"`
+	Actors    []expvarActor `json:"actors"`
+	Ready     int           `json:"ready"`
+	Total     int           `json:"total"`
+	LastError string        `json:"last_error,omitempty"`
+}
+
+// expvarActor is the state of an actor published by WithExpvar.
+type expvarActor struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// expvarObserver is the Observer installed by WithExpvar.
+type expvarObserver struct {
+	mu      sync.Mutex
+	status  int32
+	actors  []expvarActor
+	states  map[int]int // registration index to the position in actors
+	ready   int
+	lastErr error
+}
+
+func (o *expvarObserver) value() any {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	s := expvarState{
+		Status: groupStatuses[o.status],
+		Actors: append([]expvarActor{}, o.actors...),
+		Ready:  o.ready,
+		Total:  len(o.actors),
+	}
+
+	if o.lastErr != nil {
+		s.LastError = o.lastErr.Error()
+	}
+
+	return s
+}
+
+func (o *expvarObserver) set(actor ActorInfo, state ActorState, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	i, ok := o.states[actor.Index]
+	if !ok {
+		return
+	}
+
+	a := &o.actors[i]
+	if state == Stopping && a.State == Stopped.String() {
+		return
+	}
+
+	if a.State == Ready.String() {
+		o.ready--
+	}
+
+	if state == Ready {
+		o.ready++
+	}
+
+	a.State = state.String()
+	if err != nil {
+		a.Error = err.Error()
+		o.lastErr = err
+	}
+}
+
+func (o *expvarObserver) OnGroupStart(actors []ActorInfo, _ Clock) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.status = groupRunning
+	o.actors = make([]expvarActor, len(actors))
+	o.states = make(map[int]int, len(actors))
+	o.ready = 0
+
+	for i, a := range actors {
+		o.actors[i] = expvarActor{Name: a.Name, State: WaitingDeps.String()}
+		o.states[a.Index] = i
+	}
+}
+
+func (o *expvarObserver) OnActorStart(actor ActorInfo) { o.set(actor, Running, nil) }
+func (o *expvarObserver) OnActorReady(actor ActorInfo) { o.set(actor, Ready, nil) }
+
+func (o *expvarObserver) OnActorExit(actor ActorInfo, err error) { o.set(actor, Stopped, err) }
+
+func (o *expvarObserver) OnInterrupt(actor ActorInfo, _ error) {
+	o.mu.Lock()
+	if o.status == groupRunning {
+		o.status = groupStopping
+	}
+	o.mu.Unlock()
+
+	o.set(actor, Stopping, nil)
+}
+
+func (o *expvarObserver) OnGroupDone(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.status = groupStopped
+	o.ready = 0
+
+	for i := range o.actors {
+		o.actors[i].State = Stopped.String()
+	}
+
+	if err != nil {
+		o.lastErr = err
+	}
+}
@@ -0,0 +1,129 @@
+package deprun_test
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+type expvarState struct {
+	Status string `json:"status"`
+	Actors []struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+		Error string `json:"error"`
+	} `json:"actors"`
+	Ready     int    `json:"ready"`
+	Total     int    `json:"total"`
+	LastError string `json:"last_error"`
+}
+
+func readExpvar(t *testing.T, name string) expvarState {
+	t.Helper()
+
+	var s expvarState
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &s); err != nil {
+		t.Error(err)
+	}
+
+	return s
+}
+
+func TestWithExpvar(t *testing.T) {
+	g := deprun.New(deprun.WithExpvar("deprun_test_group"))
+
+	if want, have := "idle", readExpvar(t, "deprun_test_group").Status; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	var during expvarState
+	myError := errors.New("boom")
+	g.AddWith(func() error {
+		during = readExpvar(t, "deprun_test_group")
+		return myError
+	}, func(error) {}, db, deprun.Name("api"))
+
+	never := g.AddDepWith(func(deprun.ReadySignal) error { <-stop; return nil }, func(error) {}, deprun.Name("cache"))
+	g.AddWith(func() error { return nil }, func(error) {}, never, deprun.Name("worker"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if want, have := "running", during.Status; want != have {
+		t.Errorf("status: want %q, have %q", want, have)
+	}
+
+	if want, have := deprun.Ready.String(), during.Actors[0].State; want != have {
+		t.Errorf("db: want %q, have %q", want, have)
+	}
+
+	if want, have := 4, during.Total; want != have {
+		t.Errorf("total: want %d, have %d", want, have)
+	}
+
+	after := readExpvar(t, "deprun_test_group")
+	for i, want := range []string{"db", "api", "cache", "worker"} {
+		if have := after.Actors[i].Name; want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+
+		if want, have := deprun.Stopped.String(), after.Actors[i].State; want != have {
+			t.Errorf("%s: want %q, have %q", after.Actors[i].Name, want, have)
+		}
+	}
+
+	if want, have := "boom", after.Actors[1].Error; want != have {
+		t.Errorf("api: want %q, have %q", want, have)
+	}
+
+	if want, have := "stopped", after.Status; want != have {
+		t.Errorf("status: want %q, have %q", want, have)
+	}
+
+	if want, have := "boom", after.LastError; want != have {
+		t.Errorf("last error: want %q, have %q", want, have)
+	}
+
+	if want, have := 0, after.Ready; want != have {
+		t.Errorf("ready: want %d, have %d", want, have)
+	}
+}
+
+func TestWithExpvarClone(t *testing.T) {
+	g := deprun.New(deprun.WithExpvar("deprun_test_clone"))
+
+	var during expvarState
+	stop := make(chan struct{})
+	g.AddWith(func() error { <-stop; return nil }, func(error) { close(stop) }, deprun.Name("job"))
+	g.AddWith(func() error {
+		during = readExpvar(t, "deprun_test_clone")
+		return nil
+	}, func(error) {}, deprun.Name("job"))
+
+	if err := g.Clone().Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := "running", during.Status; want != have {
+		t.Errorf("status: want %q, have %q", want, have)
+	}
+
+	if want, have := 2, len(during.Actors); want != have {
+		t.Fatalf("want %d actors, have %d", want, have)
+	}
+
+	if want, have := "stopped", readExpvar(t, "deprun_test_clone").Status; want != have {
+		t.Errorf("status: want %q, have %q", want, have)
+	}
+}
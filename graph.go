@@ -0,0 +1,122 @@
+package deprun
+
+// providerIndices maps the dependencies provided by actors to the indices
+// of the providing actors.
+func providerIndices(actors []actor) map[*Dependency]int {
+	providers := make(map[*Dependency]int, len(actors))
+	for i := range actors {
+		providers[actors[i].provides] = i
+	}
+
+	return providers
+}
+
+// edges returns the indices of the actors a depends on, directly or through
+// external dependencies, in order and without duplicates. external reports
+// whether a also depends on something no actor provides, such as a
+// dependency of another group.
+func (a *actor) edges(providers map[*Dependency]int) (deps []int, external bool) {
+	seen := make(map[*Dependency]bool)
+
+	var walk func(d *Dependency)
+	walk = func(d *Dependency) {
+		if d == nil || seen[d] {
+			return
+		}
+
+		seen[d] = true
+
+		if i, ok := providers[d]; ok {
+			deps = append(deps, i)
+
+			return
+		}
+
+		if d.source == nil || len(d.sourceDeps) == 0 {
+			external = true
+
+			return
+		}
+
+		for _, dep := range d.sourceDeps {
+			walk(dep)
+		}
+	}
+
+	for _, d := range a.dependsOn {
+		walk(d)
+	}
+
+	return deps, external
+}
+
+// levels returns the startup level of each of actors: 0 for actors that do
+// not depend on other actors, and otherwise one more than the highest level
+// among their dependencies. Startup phases count as dependencies.
+func levels(actors []actor) []int {
+	actors = phased(actors)
+	providers := providerIndices(actors)
+
+	const visiting = -1
+
+	result := make([]int, len(actors))
+	done := make([]bool, len(actors))
+
+	var level func(i int) int
+	level = func(i int) int {
+		if done[i] {
+			return result[i]
+		}
+
+		result[i] = visiting // a cycle only yields a wrong level
+		deps, _ := actors[i].edges(providers)
+
+		l := 0
+		for _, dep := range deps {
+			if dep != i && result[dep] != visiting {
+				l = max(l, level(dep)+1)
+			}
+		}
+
+		result[i], done[i] = l, true
+
+		return l
+	}
+
+	for i := range actors {
+		level(i)
+	}
+
+	return result
+}
+
+// Levels returns the names of the actors grouped by startup level: level 0
+// holds the actors that do not depend on other actors of the group, and
+// each following level the actors whose dependencies are all in preceding
+// levels, startup phases included. Within a level, actors are in
+// registration order. Levels describes the graph; it does not run anything.
+func (g *Group) Levels() [][]string {
+	actors := g.graph()
+
+	var result [][]string
+	for i, l := range levels(actors) {
+		for len(result) <= l {
+			result = append(result, nil)
+		}
+
+		result[l] = append(result[l], actors[i].String())
+	}
+
+	return result
+}
+
+// StartupOrder returns the names of the actors in a valid startup order:
+// every actor comes after the actors it depends on. It is Levels flattened.
+func (g *Group) StartupOrder() []string {
+	var order []string
+	for _, level := range g.Levels() {
+		order = append(order, level...)
+	}
+
+	return order
+}
@@ -0,0 +1,29 @@
+package deprun_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestLevels(t *testing.T) {
+	var g deprun.Group
+
+	nop := func(deprun.ReadySignal) error { return nil }
+	api := g.Actor("api")
+	db := g.AddDepWith(nop, nil, deprun.Name("db"))
+	cache := g.AddDepWith(nop, nil, db, deprun.Name("cache"))
+	api.ExecuteReady(nop).DependsOn(db, cache).Register()
+	g.AddDepWith(nop, nil, deprun.Name("metrics"))
+	g.Phase(1).AddWith(func() error { return nil }, nil, deprun.Name("warmup"))
+
+	want := [][]string{{"db", "metrics"}, {"cache"}, {"api"}, {"warmup"}}
+	if have := g.Levels(); !slices.EqualFunc(want, have, slices.Equal) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := []string{"db", "metrics", "cache", "api", "warmup"}, g.StartupOrder(); !slices.Equal(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
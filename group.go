@@ -5,11 +5,79 @@
 // from net.Listeners, or scanning input from a closable io.Reader.
 package deprun
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"runtime/trace"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 // Group collects actors (functions) and runs them concurrently.
 // When one actor (function) returns, all actors are interrupted.
 // The zero value of a Group is useful.
+//
+// Actors may be added from several goroutines concurrently, e.g. by the init
+// code of different packages, but not while the group is running: adding an
+// actor, or calling Run, while Run is in progress panics.
+//
+// A group runs once: its dependencies are resolved for good, so calling Run
+// again panics. To run the same actors again, Clone the group before its
+// first run and run a fresh clone each time.
 type Group struct {
-	actors []actor
+	mu         sync.Mutex // guards actors, hooks, ready, checks and observers
+	actors     []actor
+	hooks      []func() error
+	finalizers []func(error)
+	ready      *Dependency
+	status     atomic.Int32
+	checks     []healthCheck
+
+	startLimit      int
+	startRate       int
+	startRatePer    time.Duration
+	startupTimeout  time.Duration
+	shutdownTimeout time.Duration
+	maxRuntime      time.Duration
+	stallTimeout    time.Duration
+	onStall         func([]StalledActor)
+	onProgress      func(ready, total int, lastReady string)
+	onMissedBeat    func(actor string, silent time.Duration)
+	slowInterrupt   time.Duration
+	onSlowInterrupt func(actor string)
+	errorFilter     func(error) error
+	waitAll         bool
+	teardownWhen    func(exits []Exit, total int) bool
+	runToCompletion bool
+	chaos           *Chaos
+	orderSeed       *uint64
+	clock           Clock
+	leakCheck       bool
+	baseCtx         context.Context
+	name            string
+	observers       observers
+
+	run      atomic.Pointer[[]actor] // the actors of the current or last run
+	progress progress                // see WithProgress
+}
+
+// Group lifecycle, as stored in Group.status.
+const (
+	groupIdle int32 = iota
+	groupRunning
+	groupStopping
+	groupStopped
+)
+
+var groupStatuses = map[int32]string{
+	groupIdle:     "idle",
+	groupRunning:  "running",
+	groupStopping: "stopping",
+	groupStopped:  "stopped",
 }
 
 // AddDep adds a runnable that may resolve a dependency.
@@ -21,15 +89,85 @@ type Group struct {
 // call the ready function to signal that it is ready and that dependent
 // actors can start.
 func (g *Group) AddDep(execute func(ready ReadySignal) error, interrupt func(error), dependsOn ...*Dependency) *Dependency {
-	actor := actor{execute, interrupt, newDependency(), dependsOn}
-	g.actors = append(g.actors, actor)
+	return g.AddDepWith(execute, interrupt, DependsOn(dependsOn...))
+}
 
-	return actor.provides
+// AddDepWith adds an actor like AddDep, configured by actor options such as
+// Name, NonCritical or DependsOn.
+func (g *Group) AddDepWith(execute func(ready ReadySignal) error, interrupt func(error), opts ...ActorOption) *Dependency {
+	return g.add(actor{execute: execute, interrupt: interrupt, provider: true}, opts)
+}
+
+// add registers a, after applying opts, and returns the Dependency it
+// provides.
+func (g *Group) add(a actor, opts []ActorOption) *Dependency {
+	g.prepare(&a, opts)
+
+	g.update("actor added", func() {
+		a.index = len(g.actors)
+		g.actors = append(g.actors, a)
+	})
+
+	return a.provides
+}
+
+// prepare readies a for registration and applies opts. It keeps the
+// Dependency a provides, if set already.
+func (g *Group) prepare(a *actor, opts []ActorOption) {
+	if a.provides == nil {
+		a.provides = newDependency()
+	}
+	a.state = new(actorState)
+	a.site = callSite()
+	a.group = g.name
+	if a.interrupt == nil {
+		a.interrupt = func(error) {}
+	}
+	for _, opt := range opts {
+		opt.applyActor(a)
+	}
+	if a.provides.idle != nil {
+		a.provides.idle.clock = g.clockOrSystem()
+	}
+}
+
+// update runs fn with g locked. It panics if the group is running: actors,
+// health checks and observers are fixed for the duration of a run.
+func (g *Group) update(what string, fn func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.running() {
+		panic("deprun: " + what + " while the group is running")
+	}
+
+	fn()
+}
+
+// running reports whether Run is in progress.
+func (g *Group) running() bool {
+	switch g.status.Load() {
+	case groupRunning, groupStopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// registered returns the actors added so far.
+func (g *Group) registered() []actor {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return slices.Clip(g.actors)
 }
 
 // Add an actor (function) to the group. Each actor must be pre-emptable by an
 // interrupt function. That is, if interrupt is invoked, execute should return.
 // Also, it must be safe to call interrupt even after execute has returned.
+// Interrupt may be nil for an actor that is pre-empted by other means, e.g.
+// by the interrupt of another actor or a context canceled on teardown; it
+// then defaults to a no-op.
 //
 // The first actor (function) to return interrupts all running actors.
 // The error is passed to the interrupt functions, and is returned by Run.
@@ -37,68 +175,761 @@ func (g *Group) AddDep(execute func(ready ReadySignal) error, interrupt func(err
 // To create a dependency, pass one or more *Dependency objects to Add.
 // The actor will only start after all of its dependencies have signaled
 // that they are ready. If no dependencies are provided, the actor starts
-// immediately.
+// immediately. To configure the actor further, use AddWith.
 func (g *Group) Add(execute func() error, interrupt func(error), dependsOn ...*Dependency) {
-	g.AddDep(func(ReadySignal) error { return execute() }, interrupt, dependsOn...)
+	g.AddWith(execute, interrupt, DependsOn(dependsOn...))
+}
+
+// AddWith adds an actor like Add, configured by actor options such as Name,
+// NonCritical or DependsOn:
+//
+//	g.AddWith(execute, interrupt, deprun.DependsOn(db), deprun.Name("api"))
+func (g *Group) AddWith(execute func() error, interrupt func(error), opts ...ActorOption) {
+	g.add(actor{execute: addExecute(execute), interrupt: interrupt}, opts)
+}
+
+// addExecute adapts the execute func of Add. Such an actor is considered
+// ready as soon as it starts. A nil execute stays nil, see Validate.
+func addExecute(execute func() error) func(ReadySignal) error {
+	if execute == nil {
+		return nil
+	}
+
+	return func(ready ReadySignal) error {
+		ready()
+
+		return execute()
+	}
+}
+
+// Ready returns a Dependency that becomes ready once every actor added with
+// AddDep has signaled that it is ready. The set of actors is fixed when Run
+// is called. A group without such actors is ready as soon as it runs.
+//
+// Ready is useful to notify the outside world, e.g. a service manager or a
+// load balancer, that the whole group has started.
+func (g *Group) Ready() *Dependency {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.ready == nil {
+		g.ready = newDependency()
+	}
+
+	return g.ready
 }
 
 // Run all actors (functions) concurrently.
 // When the first actor returns, all others are interrupted.
-// Run only returns when all actors have exited.
+// Run only returns when all actors have exited, see WithLeakCheck.
 // Run returns the error returned by the first exiting actor.
+// Actors marked NonCritical are exempt: they may exit without tearing down
+// the group. WithWaitAll and WithTeardownWhen change when an exit tears the
+// group down; Run then returns the first non-nil error among the exits so
+// far. If every actor finishes without triggering teardown, Run returns nil.
+// With WithRunToCompletion, Run returns the joined errors of all failed
+// actors.
 func (g *Group) Run() error {
-	if len(g.actors) == 0 {
+	return g.begin("Run").run(nil)
+}
+
+// prepared is what a run of the group is made of, fixed by begin.
+type prepared struct {
+	g          *Group
+	registered []actor
+	hooks      []func() error
+	finalizers []func(error)
+}
+
+// begin marks the group running and takes a snapshot of its registrations.
+// It panics if the group is running or already ran.
+func (g *Group) begin(caller string) prepared {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.running() {
+		panic("deprun: " + caller + " called while the group is running")
+	}
+
+	if g.status.Load() == groupStopped {
+		panic("deprun: " + caller + " called on a group that already ran; run a Clone instead")
+	}
+
+	g.status.Store(groupRunning)
+	if g.ready == nil {
+		g.ready = newDependency()
+	}
+
+	return prepared{
+		g:          g,
+		registered: slices.Clip(g.actors),
+		hooks:      slices.Clip(g.hooks),
+		finalizers: slices.Clip(g.finalizers),
+	}
+}
+
+// run runs the group until it is torn down by an actor or by an error
+// received from stop, which may be nil.
+func (s prepared) run(stop <-chan error) error {
+	g, registered, hooks, finalizers := s.g, s.registered, s.hooks, s.finalizers
+
+	defer g.status.Store(groupStopped)
+
+	for _, hook := range hooks {
+		if err := hook(); err != nil {
+			g.ready.interrupt()
+			g.observers.OnGroupStart(nil, g.clockOrSystem())
+			finalize(finalizers, err)
+			g.observers.OnGroupDone(err)
+
+			return err
+		}
+	}
+
+	if len(registered) == 0 {
+		g.observers.OnGroupStart(nil, g.clockOrSystem())
+		finalize(finalizers, nil)
+		g.observers.OnGroupDone(nil)
+
 		return nil
 	}
 
-	// Run each actor.
-	errors := make(chan error, len(g.actors))
-	for _, a := range g.actors {
-		go func(a actor) {
-			if !a.WaitDeps() {
-				errors <- nil
+	actors := phased(resolveTags(registered))
+	disable(actors)
+	clock := g.clockOrSystem()
+	actors = append(actors, externalActors(actors, clock)...)
+	if g.startupTimeout > 0 {
+		actors = append(actors, startupDeadline(clock, g.startupTimeout, g.ready, actors))
+	}
+
+	if g.maxRuntime > 0 {
+		actors = append(actors, maxRuntime(clock, g.maxRuntime))
+	}
 
-				return // interrupted
+	if g.onStall != nil {
+		actors = append(actors, stallWatchdog(clock, g.stallTimeout, g.onStall, actors))
+	}
+
+	markDependedOn(actors)
+	restartSubtrees(actors)
+	g.planChaos(actors)
+	g.sequence(actors)
+	g.resetProgress(actors)
+
+	if len(g.observers) > 0 {
+		infos := make([]ActorInfo, 0, len(actors))
+		for i := range actors {
+			if !actors[i].hidden && !actors[i].disabled {
+				infos = append(infos, actors[i].info())
 			}
+		}
+
+		g.observers.OnGroupStart(infos, clock)
+	}
+
+	var (
+		limiter  = newStartLimiter(g.startLimit)
+		rate     = newStartRate(g.startRate, g.startRatePer)
+		stopping = make(chan struct{})
+		halt     = newDependency() // resolved once stopping is closed
+	)
+
+	// Trace the group and each actor as runtime/trace tasks.
+	ctx, task := trace.NewTask(context.Background(), "deprun.Run")
+	defer task.End()
+
+	for i := range actors {
+		a := &actors[i]
+		a.clock = clock
+		if a.lazy {
+			a.resetState(Pending)
+		} else {
+			a.resetState(WaitingDeps)
+		}
+		a.armExecute()
+		g.armContext(a)
+		if a.breaker != nil {
+			a.breaker.reset()
+		}
+		g.armRestarts(a)
+		a.interruptOnce()
+		a.deps = awaitDeps(a.dependsOn)
+		a.ctx, a.trace = trace.NewTask(ctx, "deprun.actor")
+		trace.Log(a.ctx, "actor", a.String())
+	}
+
+	g.run.Store(&actors)
+
+	g.watchRearmable(actors)
 
-			errors <- a.execute(a.provides.ready)
-		}(a)
+	// Run each actor. Exits are handed over unbuffered while Run watches
+	// them; once the teardown begins, only running tracks the actors.
+	exits := make(chan exit)
+	var running sync.WaitGroup
+	running.Add(len(actors))
+	for i := range actors {
+		a := &actors[i]
+		a.exited = make(chan struct{})
 	}
 
-	// Wait for the first actor to stop.
-	err := <-errors
+	for i := range actors {
+		a := &actors[i]
+		a.whenStartable(func() {
+			go func() {
+				defer running.Done()
+				defer close(a.exited)
+
+				pprof.Do(a.ctx, a.labels(), func(ctx context.Context) {
+					g.runActor(ctx, a, limiter, rate, stopping, exits)
+				})
+			}()
+		}, halt)
+	}
+
+	// Track readiness of the whole group.
+	readyDone := make(chan struct{})
+	go func() {
+		defer close(readyDone)
+		g.waitReady(registered)
+	}()
+
+	// Wait until an exit triggers teardown, or for all actors to finish.
+	// Hidden actors only exit on failure.
+	var (
+		remaining int
+		total     int
+		history   []Exit
+		failures  []error
+		err       error
+	)
+
+	for _, a := range actors {
+		if !a.hidden && !a.lazy {
+			remaining++
+
+			if !a.nonCritical && !a.task && !a.disabled {
+				total++
+			}
+		}
+	}
+
+	var (
+		trigger      *exit
+		stopped      bool
+		partitionErr error // of the first failed partition
+	)
+
+	for trigger == nil && !stopped && remaining > 0 {
+		var e exit
+		select {
+		case e = <-exits:
+		case stopErr := <-stop:
+			if err == nil {
+				err = stopErr
+			}
+			stopped = true
+
+			continue
+		}
+
+		if !e.actor.hidden && !e.actor.lazy {
+			remaining--
+		}
+
+		// A hidden actor returns nil only if its dependencies failed; its
+		// dependents report that they never started.
+		if e.actor.hidden && e.err == nil {
+			e.done()
+
+			continue
+		}
+
+		// Actors stopped by InterruptTag leave the rest of the group alone,
+		// and disabled actors never ran.
+		if e.actor.targeted() || e.actor.disabled {
+			e.done()
+
+			continue
+		}
+
+		if g.runToCompletion && !e.actor.hidden {
+			if e.err != nil && !errors.Is(e.err, ErrNeverStarted) {
+				failures = append(failures, fmt.Errorf("%s: %w", e.actor, e.err))
+			}
+
+			e.done()
+
+			continue
+		}
+
+		if e.actor.nonCritical || (e.actor.task && e.err == nil) {
+			e.done()
+
+			continue
+		}
+
+		if p := e.actor.partition; p != "" && !e.actor.hidden {
+			if partitionErr == nil {
+				partitionErr = e.err
+			}
+
+			g.stopPartition(actors, p, e.err)
+			e.done()
+
+			continue
+		}
+
+		if err == nil {
+			err = e.err
+		}
+
+		if e.actor.hidden || e.actor.task {
+			trigger = &e
+
+			continue
+		}
+
+		history = append(history, Exit{Name: e.actor.String(), Err: e.err})
+		if g.triggersTeardown(history, total) {
+			trigger = &e
+
+			continue
+		}
+
+		e.done()
+	}
+
+	if trigger == nil && !stopped && err == nil {
+		err = partitionErr
+	}
+
+	g.status.Store(groupStopping)
+	close(stopping)
+	halt.interrupt() // launches the actors still waiting on dependencies
+
+	teardown := trace.StartRegion(ctx, "teardown")
+
+	if trigger != nil {
+		trigger.done()
+	}
+
+	if g.runToCompletion {
+		err = errors.Join(append(failures, err)...)
+	}
+
+	// Signal all actors to stop, telling them whether the teardown comes
+	// from outside.
+	cause := err
+	if stopped {
+		cause = &InterruptedError{Cause: err}
+	}
+
+	shutdownCtx, cancel := g.shutdownContext()
+	defer cancel()
+
+	var interrupts sync.WaitGroup
+	for i := range actors {
+		a := &actors[i]
+		if a.disabled || (a.lazy && a.currentState() == Pending) {
+			continue
+		}
 
-	// Signal all actors to stop.
-	for _, a := range g.actors {
 		a.provides.interrupt()
-		a.interrupt(err)
+		a.setState(Stopping)
+
+		if !a.hidden {
+			g.observers.OnInterrupt(a.info(), cause)
+		}
+
+		if g.onSlowInterrupt != nil && !a.hidden {
+			interrupts.Go(func() { g.watchInterrupt(a) })
+		}
+
+		if a.shutdown != nil {
+			interrupts.Go(func() {
+				trace.WithRegion(a.ctx, "interrupt", func() { a.shutdown(shutdownCtx, cause) })
+			})
+		} else {
+			trace.WithRegion(a.ctx, "interrupt", func() { a.interrupt(cause) })
+		}
+
+		if a.force != nil {
+			interrupts.Go(func() { a.forceInterrupt(cause) })
+		}
 	}
 
+	// The group may have become ready just before the teardown, without
+	// waitReady having noticed yet.
+	if providersReady(registered) {
+		g.ready.ready()
+	} else {
+		g.ready.interrupt()
+	}
+
+	<-readyDone
+
 	// Wait for all actors to stop.
-	for i := 1; i < cap(errors); i++ {
-		<-errors
+	if leaked := g.awaitExits(actors, &running, &interrupts, shutdownCtx); leaked != nil {
+		err = errors.Join(err, leaked)
 	}
 
+	teardown.End()
+
+	for i := range actors {
+		actors[i].trace.End()
+	}
+
+	finalize(finalizers, err)
+	g.observers.OnGroupDone(err)
+
 	// Return the original error.
 	return err
 }
 
-type actor struct {
-	execute   func(ready ReadySignal) error
-	interrupt func(error)
-	provides  *Dependency   // depend on me
-	dependsOn []*Dependency // i'm dependent
+// runActor runs a once its dependencies are ready, and sends its exit.
+func (g *Group) runActor(ctx context.Context, a *actor, limiter *startLimiter, rate *startRate, stopping <-chan struct{}, exits chan<- exit) {
+	send := func(e exit) {
+		// Dependents of an actor that exits before it is ready never
+		// start; they can tell whether it failed or was interrupted.
+		a.provides.fail(e.err)
+		a.setErr(e.err)
+		a.setState(Stopped)
+		a.endTurn()
+		e.report(exits, stopping)
+	}
+
+	if a.disabled {
+		a.provides.ready()
+		a.setState(Disabled)
+		exit{actor: a}.report(exits, stopping)
+
+		return
+	}
+
+	if a.lazy && !a.awaitDemand(stopping) {
+		send(exit{actor: a, err: a.neverStarted()})
+
+		return // interrupted
+	}
+
+	var ok bool
+	trace.WithRegion(ctx, "wait dependencies", func() { ok = a.WaitDeps(stopping) })
+
+	if !ok {
+		send(exit{actor: a, err: a.neverStarted()})
+
+		return // interrupted
+	}
+
+	if a.hidden {
+		send(exit{actor: a, err: g.filterError(a, a.execute(a.provides.ready))})
+
+		return
+	}
+
+	if !a.awaitTurn(stopping) {
+		send(exit{actor: a, err: a.neverStarted()})
+
+		return // interrupted
+	}
+
+	if a.chaos != nil && !chaosDelay(a.clock, a.chaos.startDelay, stopping) {
+		send(exit{actor: a, err: a.neverStarted()})
+
+		return // interrupted
+	}
+
+	release, ok := limiter.acquire(a.priority, stopping)
+	if !ok {
+		send(exit{actor: a, err: a.neverStarted()})
+
+		return // interrupted
+	}
+
+	if !rate.wait(a.clock, stopping) {
+		release()
+		send(exit{actor: a, err: a.neverStarted()})
+
+		return // interrupted
+	}
+
+	a.setState(Running)
+
+	// InterruptTag checks the state after marking the actor: either it sees
+	// Running and interrupts the actor, or the actor sees the mark here.
+	if a.targeted() {
+		release()
+		send(exit{actor: a, err: a.neverStarted()})
+
+		return
+	}
+
+	a.watchOptional(stopping)
+
+	info := a.info()
+	ready := func() {
+		if a.chaos != nil {
+			chaosDelay(a.clock, a.chaos.readyDelay, stopping)
+		}
+
+		release()
+
+		if a.provides.resolve() {
+			a.setState(Ready)
+			g.observers.OnActorReady(info)
+			g.reportProgress(a)
+		}
+
+		a.endTurn()
+	}
+
+	g.observers.OnActorStart(info)
+
+	var err error
+	expired, failed := g.startTimeout(a), g.startChaos(a)
+	trace.WithRegion(ctx, "execute", func() { err = g.execute(a, ready, stopping) })
+
+	if expired() {
+		err = a.timeoutError()
+	}
+
+	if failed() {
+		err = a.chaosError()
+	}
+
+	// A task is ready once it has completed successfully.
+	if a.task && err == nil {
+		ready()
+	}
+
+	if a.neverReady(err, stopping) {
+		err = &DependencyNeverReadyError{Provider: a.qualified()}
+	}
+
+	g.observers.OnActorExit(info, err)
+	send(exit{a, err, release})
 }
 
-func (a *actor) WaitDeps() bool {
-	var interrupted bool
-	for _, d := range a.dependsOn {
-		if d == nil {
+// triggersTeardown reports whether the exits so far start the teardown of
+// the group.
+func (g *Group) triggersTeardown(exits []Exit, total int) bool {
+	switch {
+	case g.teardownWhen != nil:
+		return g.teardownWhen(exits, total)
+	case g.waitAll:
+		return exits[len(exits)-1].Err != nil
+	default:
+		return true
+	}
+}
+
+// exit is sent by an actor goroutine when the actor has exited. The start
+// slot of the actor, if it still holds one, is released by Run once the exit
+// has been processed, so that no other actor can start in between.
+type exit struct {
+	actor   *actor
+	err     error
+	release func()
+}
+
+func (e exit) done() {
+	if e.release != nil {
+		e.release()
+	}
+}
+
+// report hands e over to Run, unless the teardown has begun, in which case
+// Run no longer looks at exits and e is done right away.
+func (e exit) report(exits chan<- exit, stopping <-chan struct{}) {
+	select {
+	case exits <- e:
+	case <-stopping:
+		e.done()
+	}
+}
+
+// externalActors returns hidden actors resolving the external dependencies
+// that the given actors depend on, directly or through other external
+// dependencies.
+func externalActors(group []actor, clock Clock) []actor {
+	var (
+		actors  []actor
+		seen    = make(map[*Dependency]bool)
+		pending []*Dependency
+	)
+
+	for _, a := range group {
+		pending = append(pending, a.uses()...)
+	}
+
+	for len(pending) > 0 {
+		d := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if d == nil || d.source == nil || seen[d] {
 			continue
 		}
 
-		if !d.wait() {
-			interrupted = true
+		seen[d] = true
+		pending = append(pending, d.sourceDeps...)
+
+		dependsOn := d.sourceDeps
+		if d.quorum > 0 {
+			dependsOn = nil
+		}
+
+		ctx, cancel := context.WithCancelCause(ContextWithClock(context.Background(), clock))
+		actors = append(actors, actor{
+			execute: func(ready ReadySignal) error {
+				if d.quorum > 0 {
+					if err := waitQuorum(ctx, d.quorum, d.sourceDeps); err != nil {
+						if ctx.Err() != nil {
+							return nil // interrupted
+						}
+
+						return err
+					}
+				}
+
+				if err := d.source(ctx, ready); err != nil && ctx.Err() == nil {
+					return err
+				}
+
+				<-ctx.Done()
+
+				return nil
+			},
+			interrupt: func(err error) { cancel(err) },
+			provides:  d,
+			dependsOn: dependsOn,
+			hidden:    true,
+		})
+	}
+
+	return actors
+}
+
+// providersReady reports whether all providers among actors are ready,
+// without blocking.
+func providersReady(actors []actor) bool {
+	for _, a := range actors {
+		if a.awaited() && !a.provides.isReady() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// waitReady resolves g.ready once all providers among actors are ready.
+func (g *Group) waitReady(actors []actor) {
+	for _, a := range actors {
+		if a.awaited() && !a.provides.wait() {
+			return
+		}
+	}
+
+	g.ready.ready()
+}
+
+type actor struct {
+	execute    func(ready ReadySignal) error
+	interrupt  func(error)
+	provides   *Dependency   // depend on me
+	dependsOn  []*Dependency // i'm dependent
+	deps       *depWait      // the wait on dependsOn in the current run
+	provider   bool          // added with AddDep
+	dependents bool          // other actors depend on it, see markDependedOn
+	phase      int           // startup phase, see Group.Phase
+	hidden     bool          // resolves an external dependency
+	enabled    func() bool   // see Enabled
+	disabled   bool          // enabled reported false for this run
+	lazy       bool          // see Lazy
+	name       string        // see Name
+	tags       []string      // see Tags
+	site       string        // where it was registered, see callSite
+	group      string        // see WithGroupName
+	partition  string        // see Partition
+	tagDeps    []string      // see DependsOnTag
+	optional   []optionalDep // see Optional
+	index      int           // registration order
+
+	meta map[string]string // see Meta; copied on write
+
+	force      func(error) // see ForceInterrupt
+	forceGrace time.Duration
+	shutdown   func(context.Context, error)           // replaces interrupt, see AddGraceful
+	mapError   func(error) error                      // see MapError
+	rearmable  func(ReadySignal, UnreadySignal) error // replaces execute, see AddRearmable
+	ctxExecute func(context.Context) error            // replaces execute, see AddCtx
+	beating    func(ReadySignal, func()) error        // replaces execute, see AddHeartbeat
+
+	nonCritical bool          // see NonCritical
+	task        bool          // see AddTask
+	retry       *RetryPolicy  // see Retry
+	timeout     time.Duration // see Timeout
+	priority    int           // see Priority
+	idleTimeout time.Duration // see IdleTimeout
+	restart     *RetryPolicy  // see RestartSubtree
+	breaker     *breaker      // see CircuitBreaker; shared by all copies
+	chaos       *chaosPlan    // see WithChaos, set for the run
+	turn        chan struct{} // closed on its turn to start, see WithDeterministicOrder
+	clock       Clock         // the clock of the group, set for the run
+	passTurn    func()
+	restartDeps []*Dependency // the dependencies whose restart restarts it
+
+	heartbeat       time.Duration // see AddHeartbeat
+	heartbeatPolicy HeartbeatPolicy
+
+	pause, resume func() error // see Pausable
+
+	health func(context.Context) error // see WithHealthCheck
+
+	exited chan struct{}   // closed when the actor's goroutine is done
+	state  *actorState     // see Group.States; shared by all copies
+	ctx    context.Context // carries the trace task of the actor
+	trace  *trace.Task
+}
+
+// String returns the name of the actor, or its registration index if it
+// has no name.
+func (a *actor) String() string {
+	if a.name != "" {
+		return a.name
+	}
+
+	return fmt.Sprintf("#%d", a.index)
+}
+
+// labels returns the profiler labels of the goroutines of a, see
+// runtime/pprof.
+func (a *actor) labels() pprof.LabelSet {
+	if a.hidden {
+		return pprof.Labels("deprun.actor", "(dependency)")
+	}
+
+	return pprof.Labels("deprun.actor", a.String())
+}
+
+// WaitDeps blocks until the dependencies of a are resolved, and reports
+// whether all of them are ready. It reports false once stop is closed.
+func (a *actor) WaitDeps(stop <-chan struct{}) bool {
+	// Hidden actors resolve external dependencies eagerly; demand reaches
+	// their lazy dependencies through Dependency.Demand.
+	if !a.hidden {
+		for _, d := range a.dependsOn {
+			if d != nil {
+				d.Demand()
+			}
+		}
+	}
+
+	interrupted := !a.deps.wait(stop)
+
+	// Re-armable dependencies may have become unready since.
+	for _, d := range a.dependsOn {
+		if !interrupted && d != nil && d.rearm != nil && d.rearm.policy != UnreadyNotify {
+			interrupted = !d.rearm.waitAvailable()
 		}
 	}
 
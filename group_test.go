@@ -1,7 +1,13 @@
 package deprun_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -55,3 +61,163 @@ func TestMany(t *testing.T) {
 		t.Errorf("timeout")
 	}
 }
+
+func TestReady(t *testing.T) {
+	var g deprun.Group
+	release := make(chan struct{})
+	for range 3 {
+		g.AddDep(func(ready deprun.ReadySignal) error {
+			ready()
+			<-release
+			return nil
+		}, func(error) {})
+	}
+	myError := errors.New("all ready")
+	g.Add(func() error { return myError }, func(error) { close(release) }, g.Ready())
+	res := make(chan error)
+	go func() { res <- g.Run() }()
+	select {
+	case err := <-res:
+		if want, have := myError, err; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	case <-time.After(time.Second):
+		t.Error("timeout")
+	}
+}
+
+func TestProfilerLabels(t *testing.T) {
+	var g deprun.Group
+
+	var profile bytes.Buffer
+	g.AddWith(func() error {
+		return pprof.Lookup("goroutine").WriteTo(&profile, 1)
+	}, func(error) {}, deprun.Name("api"))
+
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `"deprun.actor":"api"`; !strings.Contains(profile.String(), want) {
+		t.Errorf("goroutine profile lacks label %s", want)
+	}
+}
+
+func TestExecutionTrace(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("tracing already enabled")
+	}
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var g deprun.Group
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("api"))
+	g.Run()
+	trace.Stop()
+
+	for _, want := range []string{"deprun.Run", "deprun.actor", "api", "wait dependencies", "execute", "teardown", "interrupt"} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("trace lacks %q", want)
+		}
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	var g deprun.Group
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			g.Add(func() error { return nil }, func(error) {})
+			g.HealthCheck("check", func(context.Context) error { return nil })
+		})
+	}
+	wg.Wait()
+
+	if want, have := 50, len(g.States()); want != have {
+		t.Errorf("want %d actors, have %d", want, have)
+	}
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want no error, have %v", err)
+	}
+}
+
+func TestAddWhileRunning(t *testing.T) {
+	var g deprun.Group
+
+	var recovered any
+	g.Add(func() error {
+		defer func() { recovered = recover() }()
+		g.Add(func() error { return nil }, func(error) {})
+		return nil
+	}, func(error) {})
+
+	g.Run()
+
+	if want, have := "deprun: actor added while the group is running", recovered; want != have {
+		t.Errorf("want panic %q, have %v", want, have)
+	}
+
+	// Adding after the run is fine, e.g. to run a Clone of the group.
+	g.Add(func() error { return nil }, func(error) {})
+}
+
+func TestRunWhileRunning(t *testing.T) {
+	var g deprun.Group
+
+	var recovered any
+	g.Add(func() error {
+		defer func() { recovered = recover() }()
+		g.Run()
+		return nil
+	}, func(error) {})
+
+	g.Run()
+
+	if want, have := "deprun: Run called while the group is running", recovered; want != have {
+		t.Errorf("want panic %q, have %v", want, have)
+	}
+}
+
+func TestRunTwice(t *testing.T) {
+	var g deprun.Group
+
+	dep := g.AddDep(func(ready deprun.ReadySignal) error { ready(); return nil }, func(error) {})
+	g.Add(func() error { return nil }, func(error) {}, dep)
+
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if want, have := "deprun: Run called on a group that already ran; run a Clone instead", recover(); want != have {
+			t.Errorf("want panic %q, have %v", want, have)
+		}
+	}()
+
+	g.Run()
+	t.Error("want a panic")
+}
+
+func TestNilInterrupt(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	dep := g.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, nil)
+	g.Add(func() error { <-stop; return nil }, nil, dep)
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, func(error) { close(stop) })
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
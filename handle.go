@@ -0,0 +1,55 @@
+package deprun
+
+import "sync"
+
+// Handle controls a group started with Start.
+type Handle struct {
+	stop chan error
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+// Start runs the group in a new goroutine and returns a Handle to stop it
+// and wait for it, as an alternative to the blocking Run:
+//
+//	h := g.Start()
+//	defer h.Stop(nil)
+//
+// Like Run, Start panics if the group is already running.
+func (g *Group) Start() *Handle {
+	s := g.begin("Start")
+	h := &Handle{
+		stop: make(chan error, 1),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(h.done)
+		h.err = s.run(h.stop)
+	}()
+
+	return h
+}
+
+// Stop tears the group down with err; the run then returns err, unless an
+// actor exited with an error first. The interrupt functions receive an
+// *InterruptedError wrapping it, matching ErrInterrupted. Stop does not
+// wait for the group to stop, see Wait. Only the first call has an effect,
+// and calls after the group stopped are ignored.
+func (h *Handle) Stop(err error) {
+	h.once.Do(func() { h.stop <- err })
+}
+
+// Wait blocks until the group stopped and returns the error Run would have
+// returned.
+func (h *Handle) Wait() error {
+	<-h.done
+
+	return h.err
+}
+
+// Done returns a channel that is closed once the group stopped.
+func (h *Handle) Done() <-chan struct{} {
+	return h.done
+}
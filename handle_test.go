@@ -0,0 +1,77 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestStart(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+
+	h := g.Start()
+
+	select {
+	case <-h.Done():
+		t.Fatal("group stopped before Stop")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	myError := errors.New("stopped")
+	h.Stop(myError)
+	h.Stop(nil)
+
+	if want, have := myError, h.Wait(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	select {
+	case <-h.Done():
+	default:
+		t.Error("Done not closed after Wait")
+	}
+}
+
+func TestStartActorError(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("foobar")
+	g.Add(func() error { return myError }, nil)
+
+	h := g.Start()
+
+	if want, have := myError, h.Wait(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	h.Stop(errors.New("late"))
+
+	if want, have := myError, h.Wait(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestStartStopNil(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return errors.New("interrupted")
+	}, func(error) { close(stop) })
+
+	h := g.Start()
+	h.Stop(nil)
+
+	if err := h.Wait(); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+}
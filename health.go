@@ -0,0 +1,174 @@
+package deprun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+type healthCheck struct {
+	name  string
+	check func(context.Context) error
+}
+
+// HealthStatus is the outcome of a single health check.
+type HealthStatus struct {
+	Name string
+	Err  error // nil if healthy
+}
+
+// HealthCheck registers a named health check, typically next to the actor
+// whose health it reports. Checks are run by Health; they must be safe for
+// concurrent use and should respect ctx.
+func (g *Group) HealthCheck(name string, check func(ctx context.Context) error) {
+	g.update("health check added", func() {
+		g.checks = append(g.checks, healthCheck{name, check})
+	})
+}
+
+// WithHealthCheck registers a health check of an actor, run by Health and
+// reported under the name of the actor. It is skipped while the actor is
+// not running: before it starts, once it returned, and while it is
+// disabled. check must be safe for concurrent use and should respect ctx.
+func WithHealthCheck(check func(ctx context.Context) error) ActorOption {
+	return actorOption(func(a *actor) {
+		a.health = check
+	})
+}
+
+// Health runs all registered health checks concurrently and returns their
+// statuses in registration order, those of actors, see WithHealthCheck,
+// following those registered with HealthCheck, and then those of the
+// circuit breakers, see CircuitBreaker. The error joins the errors of all
+// failing checks, each prefixed with the check's name, and is nil if every
+// check passes.
+func (g *Group) Health(ctx context.Context) ([]HealthStatus, error) {
+	g.mu.Lock()
+	checks := slices.Clip(g.checks)
+	actors := slices.Clip(g.actors)
+	g.mu.Unlock()
+
+	for i := range actors {
+		if a := &actors[i]; a.health != nil && a.running() {
+			checks = append(checks, healthCheck{a.String(), a.health})
+		}
+	}
+
+	statuses := make([]HealthStatus, len(checks))
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		statuses[i].Name = c.name
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			statuses[i].Err = c.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	statuses = append(statuses, breakerStatuses(actors, g.clockOrSystem().Now())...)
+
+	var errs []error
+	for _, s := range statuses {
+		if s.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name, s.Err))
+		}
+	}
+
+	return statuses, errors.Join(errs...)
+}
+
+// running reports whether a has started and not returned yet.
+func (a *actor) running() bool {
+	switch a.currentState() {
+	case Running, Ready, Stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// HealthHandler returns an http.Handler answering Kubernetes-style probes
+// from the state of g:
+//
+//   - /healthz (liveness) succeeds while the group is running, i.e. Run has
+//     been called and teardown has not begun;
+//   - /readyz (readiness) additionally requires that every actor added with
+//     AddDep has signaled ready, see Group.Ready, and that all health checks
+//     registered with Group.HealthCheck pass.
+//
+// Failing probes answer 503 Service Unavailable, with the names of the
+// failing health checks listed in the body; their errors are not exposed,
+// see Group.Health for those. HealthHandler must be called before Run, so
+// that readiness is tracked.
+func HealthHandler(g *Group) http.Handler {
+	ready := g.Ready()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		probe(w, g.status.Load() == groupRunning, nil)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if g.status.Load() != groupRunning || !ready.isReady() {
+			probe(w, false, nil)
+			return
+		}
+
+		statuses, err := g.Health(r.Context())
+		probe(w, err == nil, failing(statuses))
+	})
+
+	return mux
+}
+
+func probe(w http.ResponseWriter, ok bool, checks []string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("unavailable\n"))
+
+		for _, name := range checks {
+			_, _ = fmt.Fprintf(w, "%s: failing\n", name)
+		}
+
+		return
+	}
+
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// failing returns the names of the failing health checks.
+func failing(statuses []HealthStatus) []string {
+	var names []string
+	for _, s := range statuses {
+		if s.Err != nil {
+			names = append(names, s.Name)
+		}
+	}
+
+	return names
+}
+
+// HealthServer returns an actor, i.e. an execute and interrupt func, that
+// serves HealthHandler(g) on addr. Add it to g itself:
+//
+//	g.Add(deprun.HealthServer(&g, ":8081"))
+func HealthServer(g *Group, addr string) (execute func() error, interrupt func(error)) {
+	srv := &http.Server{Addr: addr, Handler: HealthHandler(g), ReadHeaderTimeout: 5 * time.Second}
+	return func() error {
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		}, func(error) {
+			_ = srv.Close()
+		}
+}
@@ -0,0 +1,151 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func probe(h http.Handler, path string) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rec.Code
+}
+
+func TestHealthHandler(t *testing.T) {
+	var g deprun.Group
+	h := deprun.HealthHandler(&g)
+
+	if want, have := http.StatusServiceUnavailable, probe(h, "/healthz"); want != have {
+		t.Errorf("/healthz before Run: want %d, have %d", want, have)
+	}
+
+	release := make(chan struct{})
+	g.AddDep(func(ready deprun.ReadySignal) error {
+		<-release
+		ready()
+		<-release
+		return nil
+	}, func(error) {})
+
+	type check struct{ healthz, readyz int }
+	checks := make(chan check)
+	myError := errors.New("done")
+	g.Add(func() error {
+		checks <- check{probe(h, "/healthz"), probe(h, "/readyz")}
+		release <- struct{}{}
+		<-release // wait for the test to inspect the ready group
+		return myError
+	}, func(error) {})
+	isReady := make(chan struct{})
+	g.Add(func() error {
+		close(isReady)
+		<-release
+		return nil
+	}, func(error) {}, g.Ready())
+
+	res := make(chan error, 1)
+	go func() { res <- g.Run() }()
+
+	if c := <-checks; c.healthz != http.StatusOK || c.readyz != http.StatusServiceUnavailable {
+		t.Errorf("while starting: want 200/503, have %d/%d", c.healthz, c.readyz)
+	}
+
+	<-isReady
+	if want, have := http.StatusOK, probe(h, "/readyz"); want != have {
+		t.Errorf("/readyz when ready: want %d, have %d", want, have)
+	}
+	close(release)
+
+	<-res
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if want, have := http.StatusServiceUnavailable, probe(h, path); want != have {
+			t.Errorf("%s after Run: want %d, have %d", path, want, have)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	var g deprun.Group
+	dbErr := errors.New("connection refused")
+	g.HealthCheck("cache", func(context.Context) error { return nil })
+	g.HealthCheck("db", func(context.Context) error { return dbErr })
+
+	statuses, err := g.Health(context.Background())
+	if !errors.Is(err, dbErr) {
+		t.Errorf("want %v, have %v", dbErr, err)
+	}
+	if want, have := "db: connection refused", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := 2, len(statuses); want != have {
+		t.Fatalf("statuses: want %d, have %d", want, have)
+	}
+	if statuses[0].Name != "cache" || statuses[0].Err != nil {
+		t.Errorf("cache: have %+v", statuses[0])
+	}
+	if statuses[1].Name != "db" || statuses[1].Err != dbErr {
+		t.Errorf("db: have %+v", statuses[1])
+	}
+}
+
+func TestHealthHandlerFailingCheck(t *testing.T) {
+	var g deprun.Group
+	h := deprun.HealthHandler(&g)
+	g.HealthCheck("db", func(context.Context) error { return errors.New("secret dsn unreachable") })
+
+	codes := make(chan [2]int, 1)
+	bodies := make(chan string, 1)
+	g.Add(func() error {
+		<-g.Ready().Done()
+		codes <- [2]int{probe(h, "/healthz"), probe(h, "/readyz")}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		bodies <- rec.Body.String()
+
+		return nil
+	}, func(error) {})
+	_ = g.Run()
+
+	if want, have := [2]int{http.StatusOK, http.StatusServiceUnavailable}, <-codes; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := "unavailable\ndb: failing\n", <-bodies; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	var g deprun.Group
+
+	apiErr := errors.New("overloaded")
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("cron"),
+		deprun.Enabled(func() bool { return false }),
+		deprun.WithHealthCheck(func(context.Context) error { return errors.New("disabled") }))
+
+	statuses := make(chan []deprun.HealthStatus, 1)
+	g.AddWith(func() error {
+		s, _ := g.Health(context.Background())
+		statuses <- s
+
+		return nil
+	}, func(error) {}, deprun.Name("api"), deprun.WithHealthCheck(func(context.Context) error { return apiErr }))
+
+	if s, err := g.Health(context.Background()); err != nil || len(s) != 0 {
+		t.Errorf("before Run: want no statuses, have %v, %v", s, err)
+	}
+
+	_ = g.Run()
+
+	want := []deprun.HealthStatus{{Name: "api", Err: apiErr}}
+	if have := <-statuses; len(have) != 1 || have[0] != want[0] {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
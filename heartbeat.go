@@ -0,0 +1,122 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrHeartbeatMissed is passed to the interrupt function of an actor added
+// with AddHeartbeat whose heartbeats stopped, and matched by the error of
+// the group it tears down with the HeartbeatTeardown policy.
+var ErrHeartbeatMissed = errors.New("heartbeat missed")
+
+// HeartbeatPolicy decides what happens to an actor added with AddHeartbeat
+// when its heartbeats stop.
+type HeartbeatPolicy int
+
+// Heartbeat policies.
+const (
+	// HeartbeatReport only reports the silence, see WithMissedHeartbeat.
+	HeartbeatReport HeartbeatPolicy = iota
+
+	// HeartbeatRestart interrupts the actor with ErrHeartbeatMissed and
+	// executes it again once it returned.
+	HeartbeatRestart
+
+	// HeartbeatTeardown interrupts the actor with ErrHeartbeatMissed and
+	// makes it exit with an error matching ErrHeartbeatMissed, which tears
+	// the group down unless the actor is NonCritical.
+	HeartbeatTeardown
+)
+
+// AddHeartbeat is like AddDep, except that execute receives a beat
+// function it must call at least every threshold while it runs, e.g. once
+// per iteration of its main loop. When the beats stop, the silence is
+// reported to WithMissedHeartbeat and policy applies, so that wedged actors
+// are detected. Like with Clone, execute must create the state that
+// interrupt acts on each time it runs, for HeartbeatRestart.
+func (g *Group) AddHeartbeat(execute func(ready ReadySignal, beat func()) error, interrupt func(error), threshold time.Duration, policy HeartbeatPolicy, opts ...ActorOption) *Dependency {
+	return g.add(actor{
+		beating:         execute,
+		interrupt:       interrupt,
+		provider:        true,
+		heartbeat:       threshold,
+		heartbeatPolicy: policy,
+	}, opts)
+}
+
+// WithMissedHeartbeat calls onMissed with the name of every actor added
+// with AddHeartbeat that has not beaten for its threshold, and for how long
+// it has been silent, e.g. to log wedged actors. It is called once per
+// silence, from a goroutine of the group, whatever the policy.
+func WithMissedHeartbeat(onMissed func(actor string, silent time.Duration)) Option {
+	return func(g *Group) {
+		g.onMissedBeat = onMissed
+	}
+}
+
+// armHeartbeat sets the execute function of an actor added with
+// AddHeartbeat, and returns the watcher of its heartbeats.
+func (g *Group) armHeartbeat(a *actor) func(*execution) {
+	var (
+		last    atomic.Int64
+		beating = a.beating
+	)
+
+	a.execute = func(ready ReadySignal) error {
+		return beating(ready, func() { last.Store(a.clock.Now().UnixNano()) })
+	}
+
+	return func(e *execution) {
+		last.Store(a.clock.Now().UnixNano())
+
+		for awaitSilence(a.clock, &last, a.heartbeat, e.done) {
+			silent := since(a.clock, time.Unix(0, last.Load()))
+			if g.onMissedBeat != nil {
+				g.onMissedBeat(a.String(), silent)
+			}
+
+			switch a.heartbeatPolicy {
+			case HeartbeatRestart:
+				if e.claim(ErrHeartbeatMissed, func(<-chan struct{}) bool { return true }) {
+					e.interrupt(ErrHeartbeatMissed)
+				}
+
+				return
+			case HeartbeatTeardown:
+				err := fmt.Errorf("deprun: %s: no heartbeat for %v: %w", a.qualified(), silent.Round(time.Millisecond), ErrHeartbeatMissed)
+				if e.claim(err, nil) {
+					e.interrupt(ErrHeartbeatMissed)
+				}
+
+				return
+			}
+
+			if !awaitBeat(a.clock, &last, a.heartbeat, e.done) {
+				return
+			}
+		}
+	}
+}
+
+// awaitBeat blocks until last, in Unix nanoseconds, changes and reports
+// true, or reports false once done is closed. It polls every quarter of
+// threshold.
+func awaitBeat(clock Clock, last *atomic.Int64, threshold time.Duration, done <-chan struct{}) bool {
+	interval := max(threshold/4, time.Millisecond)
+	timer := clock.NewTimer(interval)
+	defer timer.Stop()
+
+	for silent := last.Load(); last.Load() == silent; {
+		select {
+		case <-timer.C():
+			timer.Reset(interval)
+		case <-done:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,116 @@
+package deprun_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+// wedged returns an actor that beats a few times, then wedges until it is
+// interrupted.
+func wedged(beats int) (func(deprun.ReadySignal, func()) error, func(error), *atomic.Int32) {
+	var (
+		executions atomic.Int32
+		stop       atomic.Pointer[chan struct{}]
+	)
+
+	return func(ready deprun.ReadySignal, beat func()) error {
+			executions.Add(1)
+			c := make(chan struct{})
+			stop.Store(&c)
+			ready()
+
+			for range beats {
+				time.Sleep(2 * time.Millisecond)
+				beat()
+			}
+
+			<-c
+
+			return nil
+		}, func(error) {
+			if c := stop.Load(); c != nil {
+				close(*c)
+			}
+		}, &executions
+}
+
+func TestHeartbeatTeardown(t *testing.T) {
+	var missed atomic.Int32
+	g := deprun.New(deprun.WithMissedHeartbeat(func(actor string, silent time.Duration) {
+		missed.Add(1)
+
+		if want, have := "worker", actor; want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+	}))
+
+	execute, interrupt, _ := wedged(5)
+	g.AddHeartbeat(execute, interrupt, 20*time.Millisecond, deprun.HeartbeatTeardown, deprun.Name("worker"))
+
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+
+	if err := g.Run(); !errors.Is(err, deprun.ErrHeartbeatMissed) {
+		t.Errorf("want %v, have %v", deprun.ErrHeartbeatMissed, err)
+	}
+
+	if want, have := int32(1), missed.Load(); want != have {
+		t.Errorf("want %d missed heartbeats, have %d", want, have)
+	}
+}
+
+func TestHeartbeatRestart(t *testing.T) {
+	var g deprun.Group
+
+	execute, interrupt, executions := wedged(0)
+	g.AddHeartbeat(execute, interrupt, 10*time.Millisecond, deprun.HeartbeatRestart)
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		for executions.Load() < 3 {
+			time.Sleep(time.Millisecond)
+		}
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestHeartbeatReport(t *testing.T) {
+	var missed atomic.Int32
+	g := deprun.New(deprun.WithMissedHeartbeat(func(string, time.Duration) {
+		missed.Add(1)
+	}))
+
+	execute, interrupt, executions := wedged(0)
+	g.AddHeartbeat(execute, interrupt, 10*time.Millisecond, deprun.HeartbeatReport)
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		time.Sleep(50 * time.Millisecond)
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	// Reported once per silence, and left running.
+	if want, have := int32(1), missed.Load(); want != have {
+		t.Errorf("want %d missed heartbeats, have %d", want, have)
+	}
+	if want, have := int32(1), executions.Load(); want != have {
+		t.Errorf("want %d executions, have %d", want, have)
+	}
+}
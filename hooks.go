@@ -0,0 +1,33 @@
+package deprun
+
+import "slices"
+
+// BeforeRun registers a hook that Run calls before it starts any actor.
+// Hooks are called one after another, in registration order; if one returns
+// an error, Run returns it immediately, without starting any actor or
+// calling the remaining hooks. Hooks suit checks that must pass before
+// anything starts, such as validating configuration or verifying that a port
+// is free, and need not be modeled as actors racing each other.
+func (g *Group) BeforeRun(hook func() error) {
+	g.update("hook added", func() {
+		g.hooks = append(g.hooks, hook)
+	})
+}
+
+// AfterRun registers a finalizer that Run calls after every actor has
+// returned, right before Run returns, with the error Run returns.
+// Finalizers are called one after another, in reverse registration order,
+// also when a BeforeRun hook failed. They suit cleanup such as flushing logs
+// or metrics once the group has stopped.
+func (g *Group) AfterRun(finalizer func(err error)) {
+	g.update("finalizer added", func() {
+		g.finalizers = append(g.finalizers, finalizer)
+	})
+}
+
+// finalize calls finalizers in reverse order.
+func finalize(finalizers []func(error), err error) {
+	for _, f := range slices.Backward(finalizers) {
+		f(err)
+	}
+}
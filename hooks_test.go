@@ -0,0 +1,100 @@
+package deprun_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestBeforeRun(t *testing.T) {
+	var g deprun.Group
+
+	var calls []string
+	g.BeforeRun(func() error { calls = append(calls, "config"); return nil })
+	g.BeforeRun(func() error { calls = append(calls, "port"); return nil })
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		calls = append(calls, "actor")
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if want, have := []string{"config", "port", "actor"}, calls; !slices.Equal(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestBeforeRunError(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("invalid config")
+	g.BeforeRun(func() error { return myError })
+	g.BeforeRun(func() error {
+		t.Error("hook called after a failing hook")
+		return nil
+	})
+	g.Add(func() error {
+		t.Error("actor started after a failing hook")
+		return nil
+	}, func(error) {
+		t.Error("actor interrupted after a failing hook")
+	})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestAfterRun(t *testing.T) {
+	var g deprun.Group
+
+	var calls []string
+	var exited bool
+	g.AfterRun(func(err error) { calls = append(calls, "logs: "+err.Error()) })
+	g.AfterRun(func(err error) {
+		if !exited {
+			t.Error("finalizer called before actors exited")
+		}
+		calls = append(calls, "metrics: "+err.Error())
+	})
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, nil)
+
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		exited = true
+		return nil
+	}, func(error) { close(stop) })
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if want, have := []string{"metrics: done", "logs: done"}, calls; !slices.Equal(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestAfterRunHookError(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("invalid config")
+	g.BeforeRun(func() error { return myError })
+
+	var have error
+	g.AfterRun(func(err error) { have = err })
+
+	g.Run()
+
+	if want := myError; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
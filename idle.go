@@ -0,0 +1,89 @@
+package deprun
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrIdle is passed to the interrupt function of an actor stopped by
+// IdleTimeout.
+var ErrIdle = errors.New("actor idle")
+
+// IdleTimeout stops an actor once the Dependency it provides has not been
+// touched, see Dependency.Touch, for d: its interrupt function is called
+// with ErrIdle, and its exit does not tear the group down. The next Touch
+// executes it again, so components can scale to zero while the process
+// keeps running; combined with Lazy, the first Touch starts it.
+//
+// While the actor is stopped its dependency is not Available. Like with
+// Clone, execute must create the state that interrupt acts on, e.g. a stop
+// channel, each time it runs.
+func IdleTimeout(d time.Duration) ActorOption {
+	return actorOption(func(a *actor) {
+		a.idleTimeout = d
+		a.provides.idle = newIdle()
+		if a.provides.rearm == nil {
+			a.provides.rearm = newRearm(UnreadyNotify)
+		}
+	})
+}
+
+// idle records the activity of a dependency, see IdleTimeout.
+type idle struct {
+	last  atomic.Int64  // when the dependency was last touched, in Unix nanoseconds
+	wake  chan struct{} // signaled on Touch
+	clock Clock         // the clock of the group
+}
+
+func newIdle() *idle {
+	return &idle{wake: make(chan struct{}, 1)}
+}
+
+// Touch reports activity on the dependency, e.g. a request served by its
+// provider. It delays the stop of a provider with an IdleTimeout, and
+// executes it again if it was stopped; it also demands a Lazy provider.
+func (s *Dependency) Touch() {
+	s.Demand()
+
+	if s.idle == nil {
+		return
+	}
+
+	s.idle.last.Store(s.idle.clock.Now().UnixNano())
+	select {
+	case s.idle.wake <- struct{}{}:
+	default:
+	}
+}
+
+// watchIdle stops the execution e once the dependency of a has not been
+// touched for the idle timeout; the next Touch runs a again.
+func (a *actor) watchIdle(e *execution) {
+	id, r := a.provides.idle, a.provides.rearm
+
+	id.last.Store(id.clock.Now().UnixNano())
+	if !awaitSilence(id.clock, &id.last, a.idleTimeout, e.done) || !e.claim(ErrIdle, id.resume) {
+		return
+	}
+
+	// Only activity from now on runs the actor again.
+	select {
+	case <-id.wake:
+	default:
+	}
+
+	r.set(true)
+	e.interrupt(ErrIdle)
+}
+
+// resume blocks until the dependency is touched and reports true, or
+// reports false once stopped is closed.
+func (id *idle) resume(stopped <-chan struct{}) bool {
+	select {
+	case <-id.wake:
+		return true
+	case <-stopped:
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+package deprun_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	var (
+		g          deprun.Group
+		executions atomic.Int32
+		reasons    = make(chan error, 10)
+	)
+
+	var stop chan struct{}
+	worker := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		executions.Add(1)
+		stop = make(chan struct{})
+		ready()
+		<-stop
+
+		return nil
+	}, func(err error) {
+		reasons <- err
+		close(stop)
+	}, deprun.IdleTimeout(20*time.Millisecond), deprun.Lazy())
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		worker.Touch() // starts the lazy worker
+		await(t, worker.Available)
+
+		// Touching keeps it running.
+		for range 5 {
+			time.Sleep(5 * time.Millisecond)
+			worker.Touch()
+		}
+
+		if want, have := int32(1), executions.Load(); want != have {
+			t.Errorf("want %d executions, have %d", want, have)
+		}
+
+		// Left alone, it stops.
+		if want, have := deprun.ErrIdle, <-reasons; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+		await(t, func() bool { return !worker.Available() })
+
+		// The next touch brings it back.
+		worker.Touch()
+		await(t, worker.Available)
+
+		if want, have := int32(2), executions.Load(); want != have {
+			t.Errorf("want %d executions, have %d", want, have)
+		}
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := myError, <-reasons; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+// await polls cond until it holds, or fails the test after a second.
+func await(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Error("condition not met")
+
+			return
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package deprun
+
+import "context"
+
+// Service is a long-running service as supervised by suture, whose
+// suture.Service interface it matches. *Group is a Service.
+type Service interface {
+	Serve(ctx context.Context) error
+}
+
+var _ Service = (*Group)(nil)
+
+// ServiceHandler returns an actor, i.e. an execute and interrupt func, that
+// runs s until it returns. The context passed to Serve is derived from ctx
+// and canceled on interrupt.
+func ServiceHandler(ctx context.Context, s Service) (execute func() error, interrupt func(error)) {
+	return FuncHandler(ctx, s.Serve)
+}
+
+// Serve runs the group like RunContext, so that a supervisor such as suture
+// can run it as a service. A supervisor restarting the service needs a
+// fresh group per call, e.g. a Clone of a template group.
+func (g *Group) Serve(ctx context.Context) error {
+	return g.RunContext(ctx)
+}
+
+// Tomb is the part of a gopkg.in/tomb.v2 *Tomb used by TombHandler.
+type Tomb interface {
+	Kill(reason error)
+	Wait() error
+}
+
+// TombHandler returns an actor, i.e. an execute and interrupt func, that
+// waits for the goroutines tracked by t. The actor terminates with the
+// reason t was killed for; on interrupt t is killed with a nil reason.
+// To run a group under a tomb instead, pass the tomb context to RunContext:
+//
+//	t.Go(func() error { return g.RunContext(t.Context(nil)) })
+func TombHandler(t Tomb) (execute func() error, interrupt func(error)) {
+	return t.Wait, func(error) {
+		t.Kill(nil)
+	}
+}
@@ -0,0 +1,108 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+type service struct {
+	started chan struct{}
+}
+
+func (s service) Serve(ctx context.Context) error {
+	close(s.started)
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func TestServiceHandler(t *testing.T) {
+	var g deprun.Group
+
+	s := service{started: make(chan struct{})}
+	g.Add(deprun.ServiceHandler(context.Background(), s))
+
+	myError := errors.New("foobar")
+	g.Add(func() error {
+		<-s.started
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestGroupServe(t *testing.T) {
+	var inner deprun.Group
+
+	inner.Add(deprun.ContextHandler(context.Background()))
+
+	var g deprun.Group
+
+	started := make(chan struct{})
+	g.Add(deprun.ServiceHandler(context.Background(), service{started}))
+	g.Add(deprun.ServiceHandler(context.Background(), &inner))
+
+	myError := errors.New("foobar")
+	g.Add(func() error {
+		<-started
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+// tomb mimics gopkg.in/tomb.v2.
+type tomb struct {
+	once   sync.Once
+	dying  chan struct{}
+	reason error
+}
+
+func (t *tomb) Kill(reason error) {
+	t.once.Do(func() {
+		t.reason = reason
+		close(t.dying)
+	})
+}
+
+func (t *tomb) Wait() error {
+	<-t.dying
+
+	return t.reason
+}
+
+func TestTombHandler(t *testing.T) {
+	var g deprun.Group
+
+	tb := &tomb{dying: make(chan struct{})}
+	g.Add(deprun.TombHandler(tb))
+
+	myError := errors.New("foobar")
+	g.Add(func() error { return myError }, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if err := tb.Wait(); err != nil {
+		t.Errorf("tomb killed with %v, want nil", err)
+	}
+
+	var g2 deprun.Group
+
+	tb = &tomb{dying: make(chan struct{})}
+	g2.Add(deprun.TombHandler(tb))
+	tb.Kill(myError)
+
+	if want, have := myError, g2.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,82 @@
+package deprun
+
+import "slices"
+
+// InterruptTag interrupts, with err, the actors of the running group that
+// are tagged with tag, see Tags, without tearing down the rest of the group:
+// their exits do not count, as if they were NonCritical, e.g. to pause
+// ingestion during maintenance while the API keeps serving. Actors that
+// have not started yet never start. Dependents that did not start yet
+// never start either, and tear the group down unless they are NonCritical;
+// see InterruptTagWithDependents.
+//
+// InterruptTag returns the number of actors it interrupted. It does nothing
+// if the group is not running.
+func (g *Group) InterruptTag(tag string, err error) int {
+	return g.interruptTag(tag, err, false)
+}
+
+// InterruptTagWithDependents is like InterruptTag, and also interrupts the
+// actors depending, directly or transitively, on the tagged actors.
+func (g *Group) InterruptTagWithDependents(tag string, err error) int {
+	return g.interruptTag(tag, err, true)
+}
+
+func (g *Group) interruptTag(tag string, err error, dependents bool) int {
+	run := g.run.Load()
+	if run == nil || g.status.Load() != groupRunning {
+		return 0
+	}
+
+	actors := *run
+	targets := make([]bool, len(actors))
+	for i := range actors {
+		targets[i] = !actors[i].hidden && slices.Contains(actors[i].tags, tag)
+	}
+
+	if dependents {
+		providers := providerIndices(actors)
+		for changed := true; changed; {
+			changed = false
+			for i := range actors {
+				if targets[i] || actors[i].hidden {
+					continue
+				}
+
+				deps, _ := actors[i].edges(providers)
+				if slices.ContainsFunc(deps, func(dep int) bool { return targets[dep] }) {
+					targets[i], changed = true, true
+				}
+			}
+		}
+	}
+
+	return g.stopTargets(actors, targets, err)
+}
+
+// stopTargets interrupts, with err, the targeted actors that are not yet,
+// and returns their number. Their exits are then ignored, and those that
+// have not started never start.
+func (g *Group) stopTargets(actors []actor, targets []bool, err error) int {
+	var n int
+	for i := range actors {
+		a := &actors[i]
+		if !targets[i] || a.state.targeted.Swap(true) {
+			continue
+		}
+
+		n++
+		a.provides.interrupt()
+		if s := a.currentState(); s == Running || s == Ready {
+			g.interruptActor(a, err)
+		}
+	}
+
+	return n
+}
+
+// targeted reports whether a was stopped by InterruptTag, or with its
+// Partition, in this run.
+func (a *actor) targeted() bool {
+	return a.state != nil && a.state.targeted.Load()
+}
@@ -0,0 +1,112 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestInterruptTag(t *testing.T) {
+	var g deprun.Group
+
+	if n := g.InterruptTag("ingest", nil); n != 0 {
+		t.Errorf("interrupted %d actors of an idle group", n)
+	}
+
+	var (
+		started    = make(chan struct{})
+		ingestStop = make(chan struct{})
+		apiStop    = make(chan struct{})
+		pause      = errors.New("maintenance")
+		interrupt  error
+	)
+
+	ingest := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		close(started)
+		<-ingestStop
+
+		return errors.New("ingest stopped")
+	}, func(err error) {
+		interrupt = err
+		close(ingestStop)
+	}, deprun.Name("ingest"), deprun.Tags("ingest"))
+
+	indexStop := make(chan struct{})
+	g.AddWith(func() error {
+		<-indexStop
+		return nil
+	}, func(error) { close(indexStop) }, ingest, deprun.Name("indexer"))
+
+	g.AddWith(func() error {
+		<-apiStop
+		return nil
+	}, func(error) { close(apiStop) }, deprun.Name("api"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		<-started
+		time.Sleep(5 * time.Millisecond)
+
+		if want, have := 1, g.InterruptTag("ingest", pause); want != have {
+			t.Errorf("want %d interrupted, have %d", want, have)
+		}
+
+		<-ingestStop
+		time.Sleep(10 * time.Millisecond) // the group keeps running
+
+		if want, have := deprun.Ready, g.States()[2].State; want != have {
+			t.Errorf("api: want %v, have %v", want, have)
+		}
+
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := pause, interrupt; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestInterruptTagWithDependents(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	started := make(chan struct{})
+	ingest := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		close(started)
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) }, deprun.Tags("ingest"))
+
+	indexStop := make(chan struct{})
+	g.Add(func() error {
+		<-indexStop
+		return nil
+	}, func(error) { close(indexStop) }, ingest)
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		<-started
+		time.Sleep(5 * time.Millisecond)
+
+		if want, have := 2, g.InterruptTagWithDependents("ingest", nil); want != have {
+			t.Errorf("want %d interrupted, have %d", want, have)
+		}
+
+		<-indexStop
+
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
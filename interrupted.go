@@ -0,0 +1,37 @@
+package deprun
+
+import "errors"
+
+// ErrInterrupted is matched, via errors.Is, by the error passed to the
+// interrupt functions when the group is torn down from outside rather than
+// by a failing actor: by Handle.Stop, by RunContext when its context is
+// done, or by a SignalHandler actor receiving a signal. Actors can use it to
+// tell a clean shutdown from a failure of a peer, e.g. to flush rather than
+// discard their buffers.
+var ErrInterrupted = errors.New("interrupted")
+
+// InterruptedError is passed to the interrupt functions when the group is
+// stopped with Handle.Stop, or by RunContext. Run itself still returns the
+// cause.
+type InterruptedError struct {
+	Cause error // the error passed to Handle.Stop, or the cause of the context
+}
+
+// Error implements the error interface.
+func (e *InterruptedError) Error() string {
+	if e.Cause == nil {
+		return "deprun: interrupted"
+	}
+
+	return "deprun: interrupted: " + e.Cause.Error()
+}
+
+// Is makes errors.Is(err, ErrInterrupted) report true.
+func (e *InterruptedError) Is(target error) bool {
+	return target == ErrInterrupted
+}
+
+// Unwrap returns the cause.
+func (e *InterruptedError) Unwrap() error {
+	return e.Cause
+}
@@ -0,0 +1,68 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestErrInterrupted(t *testing.T) {
+	var g deprun.Group
+
+	interrupted := make(chan error, 2)
+	g.Add(func() error {
+		<-interrupted
+		return nil
+	}, func(err error) { interrupted <- err; interrupted <- err })
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	go func() {
+		await(t, func() bool { return g.Snapshot().Actors[0].State == deprun.Ready })
+		cancel(errors.New("deploy"))
+	}()
+
+	errc := make(chan error, 1)
+	go func() { errc <- g.RunContext(ctx) }()
+
+	err := <-interrupted
+	if !errors.Is(err, deprun.ErrInterrupted) {
+		t.Errorf("want %v, have %v", deprun.ErrInterrupted, err)
+	}
+
+	if want, have := "deprun: interrupted: deploy", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	// Run still returns the cause itself.
+	if want, have := "deploy", (<-errc).Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestErrInterruptedPeerFailed(t *testing.T) {
+	var g deprun.Group
+
+	boom := errors.New("boom")
+	g.Add(func() error { return boom }, func(error) {})
+
+	stop := make(chan struct{})
+	var interrupted error
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(err error) { interrupted = err; close(stop) })
+
+	g.Run()
+	if err := interrupted; err != boom {
+		t.Errorf("want %v, have %v", boom, err)
+	}
+}
+
+func TestSignalErrorInterrupted(t *testing.T) {
+	if err := (deprun.SignalError{Signal: syscall.SIGTERM}); !errors.Is(err, deprun.ErrInterrupted) {
+		t.Errorf("want %v to match %v", err, deprun.ErrInterrupted)
+	}
+}
@@ -0,0 +1,132 @@
+package deprun
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// whenResolved calls f once s is resolved, or right away if it already is.
+// f runs on the goroutine that resolves s, so it must not block.
+func (s *Dependency) whenResolved(f func()) {
+	s.mu.Lock()
+	if !s.notified {
+		s.callbacks = append(s.callbacks, f)
+		s.mu.Unlock()
+
+		return
+	}
+	s.mu.Unlock()
+
+	f()
+}
+
+// notify calls the callbacks queued by whenResolved. It is called once s
+// is resolved.
+func (s *Dependency) notify() {
+	s.mu.Lock()
+	callbacks := s.callbacks
+	s.callbacks, s.notified = nil, true
+	s.mu.Unlock()
+
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// depWait aggregates the wait of an actor on its dependencies during a
+// run. Instead of blocking on each dependency in turn, every dependency
+// counts pending down once resolved, and the last one resolves all.
+type depWait struct {
+	deps    []*Dependency
+	pending atomic.Int64
+	all     *Dependency // resolved once no dependency is pending
+}
+
+// awaitDeps starts waiting for deps.
+func awaitDeps(deps []*Dependency) *depWait {
+	w := &depWait{deps: deps, all: newDependency()}
+
+	w.pending.Add(1) // held until every callback is queued
+	for _, d := range deps {
+		if d != nil {
+			w.pending.Add(1)
+			d.whenResolved(w.resolve)
+		}
+	}
+	w.resolve()
+
+	return w
+}
+
+func (w *depWait) resolve() {
+	if w.pending.Add(-1) == 0 {
+		w.all.ready()
+	}
+}
+
+// wait blocks until every dependency is resolved and reports whether all
+// of them are ready. It reports false as soon as stop is closed while a
+// dependency is still pending: the teardown launches the actors still
+// waiting, see whenStartable, which then give up here.
+func (w *depWait) wait(stop <-chan struct{}) bool {
+	if !w.all.resolved() {
+		select {
+		case <-w.all.ch:
+		case <-stop:
+			return false
+		}
+	}
+
+	for _, d := range w.deps {
+		if d != nil && !d.isReady() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// remaining returns those of deps not resolved yet, without blocking. It
+// costs nothing once all of them are resolved, as tracked by w; a nil w,
+// e.g. of an actor copied before the run started, tracks nothing.
+func (w *depWait) remaining(deps []*Dependency) []*Dependency {
+	if w != nil && w.all.resolved() {
+		return nil
+	}
+
+	var pending []*Dependency
+	for _, d := range deps {
+		if d != nil && !d.resolved() {
+			pending = append(pending, d)
+		}
+	}
+
+	return pending
+}
+
+// whenStartable calls launch once a is worth a goroutine: right away for
+// hidden, lazy and disabled actors, which wait on their own; otherwise
+// once every dependency of a is resolved, whether ready or not, or once
+// halt is resolved, when the teardown begins. Actors blocked on their
+// dependencies thus cost a callback per dependency instead of a goroutine,
+// which keeps groups of many actors cheap while they start up, and still
+// run, and report that they never started, when a dependency is never
+// resolved, e.g. one of another group. The dependencies are demanded right
+// away, as WaitDeps would. launch is called at most once.
+func (a *actor) whenStartable(launch func(), halt *Dependency) {
+	if a.hidden || a.lazy || a.disabled {
+		launch()
+
+		return
+	}
+
+	for _, d := range a.dependsOn {
+		if d != nil {
+			d.Demand()
+		}
+	}
+
+	launch = sync.OnceFunc(launch)
+	a.deps.all.whenResolved(launch)
+	halt.whenResolved(launch)
+}
@@ -0,0 +1,239 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestDeferredLaunch(t *testing.T) {
+	const n = 1000
+
+	var (
+		g       deprun.Group
+		waiting int
+	)
+
+	myError := errors.New("done")
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		waiting = runtime.NumGoroutine() // dependents are not launched yet
+		ready()
+
+		return myError
+	}, func(error) {}, deprun.Name("db"))
+
+	for i := range n {
+		g.AddCtx(func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return nil
+		}, deprun.DependsOn(db), deprun.Name(fmt.Sprint("worker", i)))
+	}
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if waiting >= n {
+		t.Errorf("want fewer than %d goroutines while dependents wait, have %d", n, waiting)
+	}
+
+	for _, s := range g.States() {
+		if want, have := deprun.Stopped, s.State; want != have {
+			t.Fatalf("%s: want %v, have %v", s.Name, want, have)
+		}
+	}
+}
+
+func TestWaitDepsInterrupted(t *testing.T) {
+	var (
+		g     deprun.Group
+		other deprun.Group // never run
+	)
+
+	foreign := other.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+
+		return nil
+	}, func(error) {})
+
+	cache := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		t.Error("cache started without its dependencies")
+
+		return nil
+	}, func(error) {}, deprun.Lazy(), deprun.DependsOn(foreign), deprun.Name("cache"))
+
+	g.AddWith(func() error { return nil }, func(error) {}, cache, deprun.Name("api"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		await(t, func() bool { return g.States()[0].State == deprun.WaitingDeps })
+
+		return myError
+	}, func(error) {})
+
+	done := make(chan error, 1)
+	go func() { done <- g.Run() }()
+
+	select {
+	case err := <-done:
+		if want, have := myError, err; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run blocked on a dependency that never resolves")
+	}
+}
+
+func TestTeardownLaunchesWaitingActors(t *testing.T) {
+	var (
+		g     deprun.Group
+		other deprun.Group // never run
+	)
+
+	g.AddWith(func() error {
+		t.Error("api started without its dependencies")
+
+		return nil
+	}, func(error) {}, other.Ready(), deprun.Name("api"))
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, func(error) {})
+
+	done := make(chan error, 1)
+	go func() { done <- g.Run() }()
+
+	select {
+	case err := <-done:
+		if want, have := myError, err; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run blocked on an actor waiting for a dependency that never resolves")
+	}
+
+	if want, have := deprun.Stopped, g.States()[0].State; want != have {
+		t.Errorf("api: want %v, have %v", want, have)
+	}
+}
+
+func TestNeverResolvedDependency(t *testing.T) {
+	var (
+		g     deprun.Group
+		other deprun.Group // never run
+	)
+
+	never := other.Ready()
+	api := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		t.Error("api started without its dependencies")
+
+		return nil
+	}, func(error) {}, never, deprun.Name("api"))
+	g.AddWith(func() error {
+		t.Error("worker started without its dependencies")
+
+		return nil
+	}, func(error) {}, api, deprun.Name("worker"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		await(t, func() bool { return g.States()[0].State == deprun.WaitingDeps })
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	for _, a := range g.Snapshot().Actors[:2] {
+		if !errors.Is(a.Err, deprun.ErrNeverStarted) {
+			t.Errorf("%s: want %v, have %v", a.Name, deprun.ErrNeverStarted, a.Err)
+		}
+	}
+
+	if api.State() == deprun.DependencyReady {
+		t.Error("api: want its dependency not ready")
+	}
+}
+
+// BenchmarkRunFanOut runs n actors that all depend on a single provider,
+// until all of them have started. The goroutines metric is the number of
+// goroutines while the dependents wait for the provider.
+func BenchmarkRunFanOut(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+
+			var waiting int
+			for b.Loop() {
+				var (
+					g       deprun.Group
+					started atomic.Int64
+				)
+
+				stop := make(chan struct{})
+				db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+					waiting = runtime.NumGoroutine()
+					ready()
+					<-stop
+
+					return nil
+				}, func(error) { close(stop) }, deprun.Name("db"))
+
+				for range n {
+					g.AddCtx(func(ctx context.Context) error {
+						if started.Add(1) == int64(n) {
+							return nil
+						}
+						<-ctx.Done()
+
+						return nil
+					}, deprun.DependsOn(db))
+				}
+
+				_ = g.Run()
+			}
+
+			b.ReportMetric(float64(waiting), "goroutines")
+		})
+	}
+}
+
+// BenchmarkRunChain runs n providers, each depending on the previous one,
+// until the last one has started.
+func BenchmarkRunChain(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				var (
+					g    deprun.Group
+					prev *deprun.Dependency
+				)
+
+				for i := range n {
+					stop := make(chan struct{})
+					prev = g.AddDepWith(func(ready deprun.ReadySignal) error {
+						ready()
+						if i == n-1 {
+							return nil
+						}
+						<-stop
+
+						return nil
+					}, func(error) { close(stop) }, deprun.DependsOn(prev))
+				}
+
+				_ = g.Run()
+			}
+		})
+	}
+}
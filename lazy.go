@@ -0,0 +1,57 @@
+package deprun
+
+// Lazy defers the start of an actor until its Dependency is in demand:
+// until an actor depending on it, directly or through external
+// dependencies such as AtLeast, starts waiting for it, until it is waited
+// for with Dependency.Wait, or until Dependency.Demand is called. Rarely
+// used subsystems then cost nothing in the runs that do not need them.
+//
+// A lazy actor is Pending until it is demanded. If it never is, it is not
+// interrupted on teardown. Lazy providers do not count towards
+// Group.Ready, and a group of lazy actors alone stops at once.
+func Lazy() ActorOption {
+	return actorOption(func(a *actor) {
+		a.lazy = true
+	})
+}
+
+// Demand starts the provider of the dependency if it is Lazy, and the lazy
+// providers of the dependencies it is built from.
+func (s *Dependency) Demand() {
+	s.demandOnce.Do(func() {
+		close(s.demanded)
+
+		for _, d := range s.sourceDeps {
+			if d != nil {
+				d.Demand()
+			}
+		}
+	})
+}
+
+// awaitDemand blocks until the dependency provided by a is demanded, moves
+// a to WaitingDeps and reports false if stop is closed first. Teardown
+// closes stop before it skips the lazy actors still Pending, so either it
+// interrupts a, or a sees stop closed here.
+func (a *actor) awaitDemand(stop <-chan struct{}) bool {
+	select {
+	case <-a.provides.demanded:
+	case <-stop:
+		return false
+	}
+
+	a.setState(WaitingDeps)
+
+	select {
+	case <-stop:
+		return false
+	default:
+		return true
+	}
+}
+
+// awaited reports whether the group waits for a to be ready, see
+// Group.Ready: a provider that is not lazy.
+func (a *actor) awaited() bool {
+	return a.provider && !a.lazy
+}
@@ -0,0 +1,97 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestLazy(t *testing.T) {
+	var (
+		g       deprun.Group
+		started atomic.Bool
+	)
+
+	g.AddDepWith(func(ready deprun.ReadySignal) error {
+		started.Store(true)
+		ready()
+
+		return nil
+	}, func(error) {
+		t.Error("lazy actor interrupted without being demanded")
+	}, deprun.Lazy())
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		<-g.Ready().Done() // lazy providers do not hold up the group
+
+		if want, have := deprun.Pending, g.States()[0].State; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if started.Load() {
+		t.Error("lazy actor started without being demanded")
+	}
+
+}
+
+func TestLazyDemand(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	cache := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) }, deprun.Lazy(), deprun.Name("cache"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		if want, have := deprun.DependencyPending, cache.State(); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+
+		// Waiting for the dependency demands it.
+		if state, err := cache.Wait(context.Background()); state != deprun.DependencyReady {
+			t.Errorf("want %v, have %v (%v)", deprun.DependencyReady, state, err)
+		}
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestLazyDependent(t *testing.T) {
+	var g deprun.Group
+
+	report := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+
+		return nil
+	}, func(error) {}, deprun.Lazy(), deprun.NonCritical())
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, func(error) {}, report)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := deprun.DependencyReady, report.State(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,82 @@
+package deprun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrLeaked is matched, via errors.Is, by the error Run returns when actors
+// were still running once the shutdown timeout expired, see WithLeakCheck.
+var ErrLeaked = errors.New("actors leaked")
+
+// LeakError is joined to the error of Run, see WithLeakCheck, when actors
+// had not returned from execute once the shutdown timeout expired.
+type LeakError struct {
+	Timeout time.Duration
+	Actors  []string // names of the actors still running
+}
+
+// Error implements the error interface.
+func (e *LeakError) Error() string {
+	return fmt.Sprintf("deprun: still running %v after teardown: %s", e.Timeout, strings.Join(e.Actors, ", "))
+}
+
+// Is makes errors.Is(err, ErrLeaked) report true.
+func (e *LeakError) Is(target error) bool {
+	return target == ErrLeaked
+}
+
+// WithLeakCheck makes Run stop waiting for the actors to return once the
+// shutdown timeout set by WithShutdownTimeout expires, and join to its
+// error a *LeakError naming those still running, instead of hanging on
+// them. Their goroutines are leaked: the check makes that visible, e.g. to
+// fail a test. Without a shutdown timeout, Run waits for every actor as
+// usual.
+func WithLeakCheck() Option {
+	return func(g *Group) {
+		g.leakCheck = true
+	}
+}
+
+// awaitExits waits for the actors still running after the teardown, and
+// for the interrupt goroutines. With WithLeakCheck, it gives up once budget
+// is done and returns a *LeakError.
+func (g *Group) awaitExits(actors []actor, running, interrupts *sync.WaitGroup, budget context.Context) error {
+	var expired <-chan struct{}
+	if g.leakCheck && g.shutdownTimeout > 0 {
+		expired = budget.Done()
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(exited)
+	}()
+
+	select {
+	case <-exited:
+	case <-expired:
+		return &LeakError{Timeout: g.shutdownTimeout, Actors: leakedActors(actors)}
+	}
+
+	interrupts.Wait()
+
+	return nil
+}
+
+// leakedActors returns the names of the actors that have not returned.
+func leakedActors(actors []actor) []string {
+	var names []string
+	for i := range actors {
+		a := &actors[i]
+		if s := a.currentState(); !a.hidden && s != Stopped && s != Disabled {
+			names = append(names, a.String())
+		}
+	}
+
+	return names
+}
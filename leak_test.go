@@ -0,0 +1,68 @@
+package deprun_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestWithLeakCheck(t *testing.T) {
+	g := deprun.New(deprun.WithShutdownTimeout(20*time.Millisecond), deprun.WithLeakCheck())
+
+	release := make(chan struct{})
+	defer close(release)
+	g.AddWith(func() error {
+		<-release // ignores interrupts
+		return nil
+	}, func(error) {}, deprun.Name("stuck"))
+
+	stop := make(chan struct{})
+	g.AddWith(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("polite"))
+
+	myErr := errors.New("boom")
+	g.AddWith(func() error { return myErr }, func(error) {}, deprun.Name("failing"))
+
+	errc := make(chan error, 1)
+	go func() { errc <- g.Run() }()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return")
+	}
+
+	if !errors.Is(err, myErr) {
+		t.Errorf("want %v in %v", myErr, err)
+	}
+
+	var leak *deprun.LeakError
+	if !errors.As(err, &leak) {
+		t.Fatalf("want %T, have %v", leak, err)
+	}
+
+	if want, have := "[stuck]", fmt.Sprint(leak.Actors); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestWithLeakCheckNone(t *testing.T) {
+	g := deprun.New(deprun.WithShutdownTimeout(time.Second), deprun.WithLeakCheck())
+
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+	g.Add(func() error { return nil }, func(error) {})
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want no error, have %v", err)
+	}
+}
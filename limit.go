@@ -0,0 +1,185 @@
+package deprun
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Priority sets the start priority of an actor, zero by default. When the
+// start slots of WithStartLimit are taken, the waiting actors with the
+// highest priority start first, e.g. to bring up health endpoints before
+// heavyweight components; actors of equal priority start in the order they
+// began waiting. Without a start limit the priority has no effect.
+func Priority(p int) ActorOption {
+	return actorOption(func(a *actor) {
+		a.priority = p
+	})
+}
+
+// startLimiter bounds the number of actors that are starting concurrently.
+// A nil *startLimiter imposes no limit.
+type startLimiter struct {
+	mu      sync.Mutex
+	free    int
+	waiters []*startWaiter // by decreasing priority, then arrival
+}
+
+// startWaiter is an actor waiting for a slot; granted is closed once the
+// slot is handed over.
+type startWaiter struct {
+	priority int
+	granted  chan struct{}
+}
+
+func newStartLimiter(n int) *startLimiter {
+	if n <= 0 {
+		return nil
+	}
+
+	return &startLimiter{free: n}
+}
+
+// acquire blocks until a slot is granted to an actor with the given
+// priority, and reports false if stop is closed first. The returned release
+// func is idempotent.
+func (l *startLimiter) acquire(priority int, stop <-chan struct{}) (release func(), ok bool) {
+	if l == nil {
+		return func() {}, true
+	}
+
+	select {
+	case <-stop:
+		return nil, false
+	default:
+	}
+
+	l.mu.Lock()
+	if l.free > 0 && len(l.waiters) == 0 {
+		l.free--
+		l.mu.Unlock()
+
+		return l.releaseOnce(), true
+	}
+
+	w := &startWaiter{priority: priority, granted: make(chan struct{})}
+	i := slices.IndexFunc(l.waiters, func(o *startWaiter) bool { return o.priority < priority })
+	if i < 0 {
+		i = len(l.waiters)
+	}
+	l.waiters = slices.Insert(l.waiters, i, w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.granted:
+	case <-stop:
+	}
+
+	// Both cases may have been ready; teardown wins.
+	select {
+	case <-stop:
+		l.mu.Lock()
+		if i := slices.Index(l.waiters, w); i >= 0 {
+			l.waiters = slices.Delete(l.waiters, i, i+1)
+			l.mu.Unlock()
+
+			return nil, false
+		}
+		l.mu.Unlock()
+
+		l.release() // granted meanwhile
+
+		return nil, false
+	default:
+	}
+
+	return l.releaseOnce(), true
+}
+
+// releaseOnce returns an idempotent func releasing a slot.
+func (l *startLimiter) releaseOnce() func() {
+	var once sync.Once
+
+	return func() { once.Do(l.release) }
+}
+
+// release hands a slot to the first waiter, or frees it.
+func (l *startLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.waiters) == 0 {
+		l.free++
+
+		return
+	}
+
+	w := l.waiters[0]
+	l.waiters = l.waiters[1:]
+	close(w.granted)
+}
+
+// WithStartRate bounds how many actors may be launched within any window
+// of the given duration, e.g. to spare shared infrastructure such as DNS or
+// a database when a group of hundreds of actors starts. Actors beyond the
+// rate wait, holding their slot of WithStartLimit if any. A rate of zero or
+// less means no limit.
+func WithStartRate(n int, per time.Duration) Option {
+	return func(g *Group) {
+		g.startRate = n
+		g.startRatePer = per
+	}
+}
+
+// startRate bounds the number of actors launched per window. A nil
+// *startRate imposes no limit.
+type startRate struct {
+	mu     sync.Mutex
+	n      int
+	per    time.Duration
+	starts []time.Time // launches within the last window, oldest first
+}
+
+func newStartRate(n int, per time.Duration) *startRate {
+	if n <= 0 || per <= 0 {
+		return nil
+	}
+
+	return &startRate{n: n, per: per}
+}
+
+// wait blocks until a launch fits in the rate, and reports false if stop is
+// closed first.
+func (r *startRate) wait(clock Clock, stop <-chan struct{}) bool {
+	if r == nil {
+		return true
+	}
+
+	for {
+		r.mu.Lock()
+		now := clock.Now()
+		i := slices.IndexFunc(r.starts, func(t time.Time) bool { return now.Sub(t) < r.per })
+		if i < 0 {
+			i = len(r.starts)
+		}
+		r.starts = r.starts[i:]
+
+		if len(r.starts) < r.n {
+			r.starts = append(r.starts, now)
+			r.mu.Unlock()
+
+			return true
+		}
+
+		timer := clock.NewTimer(r.starts[0].Add(r.per).Sub(now))
+		r.mu.Unlock()
+
+		select {
+		case <-timer.C():
+		case <-stop:
+			timer.Stop()
+
+			return false
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package deprun_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestStartLimit(t *testing.T) {
+	const (
+		actors = 20
+		limit  = 3
+	)
+
+	var (
+		g        = deprun.New(deprun.WithStartLimit(limit))
+		starting atomic.Int32
+		peak     atomic.Int32
+		stop     = make(chan struct{})
+		deps     []*deprun.Dependency
+	)
+
+	for range actors {
+		deps = append(deps, g.AddDep(func(ready deprun.ReadySignal) error {
+			n := starting.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			starting.Add(-1)
+			ready()
+			<-stop
+			return nil
+		}, func(error) {}))
+	}
+
+	myError := errors.New("all started")
+	g.Add(func() error { return myError }, func(error) { close(stop) }, deps...)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+	if p := peak.Load(); p > limit {
+		t.Errorf("peak concurrent starts: want <= %d, have %d", limit, p)
+	}
+}
+
+func TestStartLimitTeardown(t *testing.T) {
+	var (
+		g       = deprun.New(deprun.WithStartLimit(1))
+		started atomic.Int32
+		myError = errors.New("failed")
+	)
+
+	for range 2 {
+		// Never ready, so the first actor to start holds the only slot.
+		g.AddDep(func(deprun.ReadySignal) error {
+			started.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return myError
+		}, func(error) {})
+	}
+
+	res := make(chan error, 1)
+	go func() { res <- g.Run() }()
+	select {
+	case err := <-res:
+		if err != myError {
+			t.Errorf("want %v, have %v", myError, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("deadlock waiting for a start slot")
+	}
+	if want, have := int32(1), started.Load(); want != have {
+		t.Errorf("started: want %d, have %d", want, have)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	var (
+		g     = deprun.New(deprun.WithStartLimit(1))
+		mu    sync.Mutex
+		order []string
+		stop  = make(chan struct{})
+	)
+
+	// Holds the only slot while the others queue up behind it.
+	g.AddDep(func(ready deprun.ReadySignal) error {
+		time.Sleep(30 * time.Millisecond)
+		ready()
+		<-stop
+		return nil
+	}, func(error) {})
+
+	queued := deprun.AfterDuration(5 * time.Millisecond)
+	var deps []*deprun.Dependency
+	for _, name := range []string{"low", "high", "health"} {
+		priority := map[string]int{"low": -1, "high": 1, "health": 2}[name]
+		deps = append(deps, g.AddDepWith(func(ready deprun.ReadySignal) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			ready()
+			<-stop
+			return nil
+		}, func(error) {}, queued, deprun.Priority(priority)))
+	}
+
+	myError := errors.New("all started")
+	g.Add(func() error { return myError }, func(error) { close(stop) }, deps...)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+	if want, have := "health high low", strings.Join(order, " "); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestStartRate(t *testing.T) {
+	const (
+		actors = 6
+		rate   = 2
+		per    = 20 * time.Millisecond
+	)
+
+	var (
+		g      = deprun.New(deprun.WithStartRate(rate, per))
+		starts atomic.Int32
+		stop   = make(chan struct{})
+		deps   []*deprun.Dependency
+	)
+
+	for range actors {
+		deps = append(deps, g.AddDep(func(ready deprun.ReadySignal) error {
+			starts.Add(1)
+			ready()
+			<-stop
+			return nil
+		}, func(error) {}))
+	}
+
+	myError := errors.New("all started")
+	g.Add(func() error { return myError }, func(error) { close(stop) }, deps...)
+
+	begin := time.Now()
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	// Seven launches at two per window take three windows.
+	if want, have := int32(actors), starts.Load(); want != have {
+		t.Errorf("want %d starts, have %d", want, have)
+	}
+	if want, have := 3*per, time.Since(begin); have < want {
+		t.Errorf("want at least %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,48 @@
+package deprun
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"syscall"
+)
+
+// Main builds a group with opts, lets setup add its actors, runs the group
+// until an actor exits or the process receives SIGINT or SIGTERM, logs the
+// outcome to the default slog logger and returns the process exit code for
+// it, see ExitCode. It implements the usual main function:
+//
+//	func main() {
+//		os.Exit(deprun.Main(func(g *deprun.Group) error {
+//			db := g.AddResource(openDB)
+//			g.Add(serve, stop, db)
+//
+//			return nil
+//		}))
+//	}
+//
+// If setup returns an error, the group does not run. Use BeforeRun within
+// setup for work that should only happen once the group is complete.
+func Main(setup func(g *Group) error, opts ...Option) int {
+	g := New(opts...)
+	if err := setup(g); err != nil {
+		slog.Error("deprun: setup failed", "error", err)
+
+		return 1
+	}
+
+	execute, interrupt := SignalHandler(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	g.AddWith(execute, interrupt, Name("signals"))
+
+	err := g.Run()
+	switch {
+	case err == nil:
+		slog.Info("deprun: stopped")
+	case errors.Is(err, ErrSignal):
+		slog.Info("deprun: stopped", "cause", err)
+	default:
+		slog.Error("deprun: stopped", "error", err)
+	}
+
+	return ExitCode(err)
+}
@@ -0,0 +1,46 @@
+package deprun_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"syscall"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestMainFunc(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for name, tc := range map[string]struct {
+		setup func(g *deprun.Group) error
+		want  int
+	}{
+		"clean": {func(g *deprun.Group) error {
+			g.Add(func() error { return nil }, nil)
+
+			return nil
+		}, 0},
+		"actor error": {func(g *deprun.Group) error {
+			g.Add(func() error { return errors.New("foobar") }, nil)
+
+			return nil
+		}, 1},
+		"signal": {func(g *deprun.Group) error {
+			g.Add(func() error { return &deprun.SignalError{Signal: syscall.SIGTERM} }, nil)
+
+			return nil
+		}, 128 + int(syscall.SIGTERM)},
+		"setup error": {func(*deprun.Group) error {
+			return errors.New("foobar")
+		}, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if want, have := tc.want, deprun.Main(tc.setup); want != have {
+				t.Errorf("want %d, have %d", want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package deprun
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrMaxRuntime is returned by Run when the group was torn down because it
+// reached the duration set by WithMaxRuntime.
+var ErrMaxRuntime = errors.New("deprun: maximum runtime reached")
+
+// WithMaxRuntime tears the group down with ErrMaxRuntime once it has run for
+// d, unless it stopped before, e.g. to bound canary runs, soak tests and
+// batch windows. The teardown is the regular one: every actor is
+// interrupted and Run waits for all of them.
+func WithMaxRuntime(d time.Duration) Option {
+	return func(g *Group) {
+		g.maxRuntime = d
+	}
+}
+
+// maxRuntime returns a hidden actor that fails with ErrMaxRuntime after d.
+func maxRuntime(clock Clock, d time.Duration) actor {
+	stop := make(chan struct{})
+
+	return actor{
+		execute: func(ReadySignal) error {
+			timer := clock.NewTimer(d)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C():
+				return ErrMaxRuntime
+			case <-stop:
+				return nil
+			}
+		},
+		interrupt: func(error) { close(stop) },
+		provides:  newDependency(),
+		hidden:    true,
+	}
+}
@@ -0,0 +1,42 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestWithMaxRuntime(t *testing.T) {
+	g := deprun.New(deprun.WithMaxRuntime(10 * time.Millisecond))
+
+	var interruptErr error
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(err error) {
+		interruptErr = err
+		close(stop)
+	})
+
+	if want, have := deprun.ErrMaxRuntime, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := deprun.ErrMaxRuntime, interruptErr; want != have {
+		t.Errorf("interrupt: want %v, have %v", want, have)
+	}
+}
+
+func TestWithMaxRuntimeNotReached(t *testing.T) {
+	g := deprun.New(deprun.WithMaxRuntime(time.Minute))
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
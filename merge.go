@@ -0,0 +1,56 @@
+package deprun
+
+// Merge moves the actors of other, with their dependencies, into g; other
+// is left empty. Its health checks, BeforeRun hooks and AfterRun finalizers
+// move too, after those of g. Dependencies returned by other, including
+// other.Ready, keep working: other.Ready becomes ready once the actors of
+// other added with AddDep are ready. Options of other, such as
+// WithStartLimit or observers, are not carried over.
+//
+// Merge lets libraries build their own groups that a program composes into
+// a single runner. It panics if either group is running or other is g.
+func (g *Group) Merge(other *Group) {
+	if other == g {
+		panic("deprun: group merged into itself")
+	}
+
+	var (
+		actors     []actor
+		hooks      []func() error
+		finalizers []func(error)
+		checks     []healthCheck
+		ready      *Dependency
+	)
+
+	other.update("group merged", func() {
+		actors, other.actors = other.actors, nil
+		hooks, other.hooks = other.hooks, nil
+		finalizers, other.finalizers = other.finalizers, nil
+		checks, other.checks = other.checks, nil
+		ready, other.ready = other.ready, nil
+	})
+
+	// Resolve the readiness of other like a phase barrier.
+	if ready != nil {
+		var providers []*Dependency
+		for _, a := range actors {
+			if a.awaited() {
+				providers = append(providers, a.provides)
+			}
+		}
+
+		b := barrier(providers)
+		ready.source, ready.sourceDeps = b.source, b.sourceDeps
+	}
+
+	g.update("group merged", func() {
+		for _, a := range actors {
+			a.index = len(g.actors)
+			g.actors = append(g.actors, a)
+		}
+
+		g.hooks = append(g.hooks, hooks...)
+		g.finalizers = append(g.finalizers, finalizers...)
+		g.checks = append(g.checks, checks...)
+	})
+}
@@ -0,0 +1,72 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestMerge(t *testing.T) {
+	var lib deprun.Group
+
+	stop := make(chan struct{})
+	db := lib.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+	libReady := lib.Ready()
+
+	var hooked, finalized bool
+	lib.BeforeRun(func() error { hooked = true; return nil })
+	lib.AfterRun(func(error) { finalized = true })
+	lib.HealthCheck("db", func(context.Context) error { return nil })
+
+	var g deprun.Group
+	myError := errors.New("done")
+	g.AddWith(func() error { return myError }, nil, db, libReady, deprun.Name("api"))
+	g.Merge(&lib)
+
+	if want, have := 0, len(lib.States()); want != have {
+		t.Errorf("merged group: want %d actors, have %d", want, have)
+	}
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if !hooked || !finalized {
+		t.Errorf("hook called: %v, finalizer called: %v", hooked, finalized)
+	}
+
+	if statuses, _ := g.Health(context.Background()); len(statuses) != 1 {
+		t.Errorf("want 1 health check, have %d", len(statuses))
+	}
+
+	states := g.States()
+	if want, have := 2, len(states); want != have {
+		t.Fatalf("want %d actors, have %d", want, have)
+	}
+
+	if want, have := "db", states[1].Name; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if want, have := deprun.DependencyReady, libReady.State(); want != have {
+		t.Errorf("merged Ready: want %v, have %v", want, have)
+	}
+}
+
+func TestMergeSelf(t *testing.T) {
+	var g deprun.Group
+
+	defer func() {
+		if want, have := "deprun: group merged into itself", recover(); want != have {
+			t.Errorf("want panic %q, have %v", want, have)
+		}
+	}()
+
+	g.Merge(&g)
+}
@@ -0,0 +1,97 @@
+package deprun
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// mermaidClasses are the Mermaid classes of the actor states.
+var mermaidClasses = [...]string{
+	Pending:     "pending",
+	WaitingDeps: "waiting",
+	Running:     "running",
+	Ready:       "ready",
+	Stopping:    "stopping",
+	Stopped:     "stopped",
+}
+
+// Mermaid writes the dependency graph of the group to w as a Mermaid
+// flowchart, which GitHub and most documentation tools render natively.
+// Every actor is a node, with an edge to each actor depending on it; actors
+// of startup phases are grouped in subgraphs, and dependencies provided
+// outside the group point from a single "external" node. If states is true,
+// the nodes are colored by the current state of the actors.
+func (g *Group) Mermaid(w io.Writer, states bool) error {
+	actors := g.graph()
+	providers := providerIndices(actors)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "flowchart LR")
+
+	phases := make(map[int][]int)
+	var order []int
+	for i := range actors {
+		p := actors[i].phase
+		if _, ok := phases[p]; !ok {
+			order = append(order, p)
+		}
+
+		phases[p] = append(phases[p], i)
+	}
+
+	for _, p := range order {
+		indent := "\t"
+		if len(phases) > 1 {
+			fmt.Fprintf(bw, "\tsubgraph phase%d [\"phase %d\"]\n", p, p)
+			indent = "\t\t"
+		}
+
+		for _, i := range phases[p] {
+			fmt.Fprintf(bw, "%sa%d[\"%s\"]\n", indent, i, mermaidLabel(actors[i].qualified()))
+		}
+
+		if len(phases) > 1 {
+			fmt.Fprintln(bw, "\tend")
+		}
+	}
+
+	var external bool
+	for i := range actors {
+		deps, ext := actors[i].edges(providers)
+		for _, dep := range deps {
+			fmt.Fprintf(bw, "\ta%d --> a%d\n", dep, i)
+		}
+
+		if ext {
+			if !external {
+				fmt.Fprintln(bw, "\texternal((external))")
+				external = true
+			}
+
+			fmt.Fprintf(bw, "\texternal --> a%d\n", i)
+		}
+	}
+
+	if states {
+		fmt.Fprintln(bw, "\tclassDef waiting fill:#fff3bf,stroke:#f59f00")
+		fmt.Fprintln(bw, "\tclassDef running fill:#d0ebff,stroke:#1c7ed6")
+		fmt.Fprintln(bw, "\tclassDef ready fill:#d3f9d8,stroke:#37b24d")
+		fmt.Fprintln(bw, "\tclassDef stopping fill:#ffe3e3,stroke:#f03e3e")
+		fmt.Fprintln(bw, "\tclassDef stopped fill:#e9ecef,stroke:#868e96")
+
+		for i := range actors {
+			if s := actors[i].currentState(); s != Pending {
+				fmt.Fprintf(bw, "\tclass a%d %s\n", i, mermaidClasses[s])
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// mermaidLabel escapes s for a quoted Mermaid label.
+func mermaidLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
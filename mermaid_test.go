@@ -0,0 +1,65 @@
+package deprun_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestMermaid(t *testing.T) {
+	var (
+		other deprun.Group
+		g     deprun.Group
+	)
+
+	db := g.AddDepWith(func(deprun.ReadySignal) error { return nil }, nil, deprun.Name("db"))
+	cache := g.AddDepWith(func(deprun.ReadySignal) error { return nil }, nil, deprun.Name(`"cache"`))
+	g.AddWith(func() error { return nil }, nil, db, cache, other.Ready(), deprun.Name("api"))
+
+	var b strings.Builder
+	if err := g.Mermaid(&b, false); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `flowchart LR
+	a0["db"]
+	a1["#quot;cache#quot;"]
+	a2["api"]
+	a0 --> a2
+	a1 --> a2
+	external((external))
+	external --> a2
+`
+	if have := b.String(); want != have {
+		t.Errorf("want\n%s\nhave\n%s", want, have)
+	}
+}
+
+func TestMermaidStates(t *testing.T) {
+	var g deprun.Group
+
+	db := g.Phase(0).AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		return nil
+	}, nil, deprun.Name("db"))
+	g.Phase(1).AddWith(func() error { return nil }, nil, db, deprun.Name("api"))
+	g.Run()
+
+	var b strings.Builder
+	if err := g.Mermaid(&b, true); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"\tsubgraph phase0 [\"phase 0\"]\n\t\ta0[\"db\"]\n\tend\n",
+		"\tsubgraph phase1 [\"phase 1\"]\n\t\ta1[\"api\"]\n\tend\n",
+		"\ta0 --> a1\n",
+		"\tclass a0 stopped\n",
+		"\tclass a1 stopped\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("want %q in\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package deprun
+
+import "maps"
+
+// Meta attaches the key/value metadata value to an actor, e.g. its owning
+// team or a runbook URL, for tooling. It is reported by ActorInfo, to
+// observers and by ActorFromContext, in the Snapshot of the group and in
+// its Plan. A later Meta with the same key replaces the value.
+func Meta(key, value string) ActorOption {
+	return actorOption(func(a *actor) {
+		meta := maps.Clone(a.meta)
+		if meta == nil {
+			meta = make(map[string]string)
+		}
+
+		meta[key] = value
+		a.meta = meta
+	})
+}
@@ -0,0 +1,42 @@
+package deprun_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestMeta(t *testing.T) {
+	seen := make(chan deprun.ActorInfo, 1)
+	g := deprun.New(deprun.WithObserver(startObserver{c: seen}))
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("api"),
+		deprun.Meta("team", "payments"), deprun.Meta("runbook", "https://runbooks.example.com/api"), deprun.Meta("team", "billing"))
+
+	buf, err := json.Marshal(g.Snapshot().Actors[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := `"meta":{"runbook":"https://runbooks.example.com/api","team":"billing"}`, string(buf); !strings.Contains(have, want) {
+		t.Errorf("want %s in %s", want, have)
+	}
+
+	if want, have := "billing", g.Plan().Steps[0].Meta["team"]; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	g.Run()
+	if want, have := "billing", (<-seen).Meta["team"]; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+// startObserver sends the actors that start to c.
+type startObserver struct {
+	deprun.NopObserver
+	c chan<- deprun.ActorInfo
+}
+
+func (o startObserver) OnActorStart(actor deprun.ActorInfo) { o.c <- actor }
@@ -0,0 +1,89 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDependencyNeverReady is matched, via errors.Is, by the error of an
+// actor added with AddDep that returned nil without signaling ready while
+// other actors depend on it.
+var ErrDependencyNeverReady = errors.New("dependency never ready")
+
+// DependencyNeverReadyError replaces the nil error of an actor added with
+// AddDep that returned without signaling ready, before the group was torn
+// down, while other actors depend on it. Its dependents never start.
+type DependencyNeverReadyError struct {
+	Provider string // the name of the actor, see Name; where it was added if unnamed
+}
+
+// Error implements the error interface.
+func (e *DependencyNeverReadyError) Error() string {
+	return fmt.Sprintf("deprun: %s returned without signaling ready", e.Provider)
+}
+
+// Is makes errors.Is(err, ErrDependencyNeverReady) report true.
+func (e *DependencyNeverReadyError) Is(target error) bool {
+	return target == ErrDependencyNeverReady
+}
+
+// ErrNeverStarted is matched, via errors.Is, by the error of an actor that
+// never started because one of its dependencies exited before it was ready.
+// Such an actor is still interrupted when the group is torn down, so that
+// resources captured by its closures can be released.
+var ErrNeverStarted = errors.New("never started")
+
+// neverStarted returns the error of a when it never started. Hidden actors
+// report no error; their dependents do.
+func (a *actor) neverStarted() error {
+	if a.hidden {
+		return nil
+	}
+
+	return fmt.Errorf("deprun: %s: %w", a.qualified(), ErrNeverStarted)
+}
+
+// markDependedOn sets the dependents flag of the actors that other actors
+// depend on, directly or through external dependencies such as phase
+// barriers.
+func markDependedOn(actors []actor) {
+	var (
+		seen    = make(map[*Dependency]bool)
+		pending []*Dependency
+	)
+
+	for i := range actors {
+		pending = append(pending, actors[i].uses()...)
+	}
+
+	for len(pending) > 0 {
+		d := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if d == nil || seen[d] {
+			continue
+		}
+
+		seen[d] = true
+		pending = append(pending, d.sourceDeps...)
+	}
+
+	for i := range actors {
+		actors[i].dependents = seen[actors[i].provides]
+	}
+}
+
+// neverReady reports whether a provider returning err left its dependents
+// waiting forever.
+func (a *actor) neverReady(err error, stopping <-chan struct{}) bool {
+	if err != nil || !a.provider || !a.dependents || a.provides.resolved() {
+		return false
+	}
+
+	select {
+	case <-stopping:
+		return false // interrupted before it was ready
+	default:
+		return true
+	}
+}
@@ -0,0 +1,93 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestDependencyNeverReady(t *testing.T) {
+	var g deprun.Group
+	db := g.AddDepWith(func(deprun.ReadySignal) error { return nil }, func(error) {}, deprun.Name("db"))
+	g.Add(func() error {
+		t.Error("dependent started")
+		return nil
+	}, func(error) {}, db)
+
+	err := g.Run()
+	if !errors.Is(err, deprun.ErrDependencyNeverReady) {
+		t.Fatalf("want %v, have %v", deprun.ErrDependencyNeverReady, err)
+	}
+
+	var neverReady *deprun.DependencyNeverReadyError
+	if !errors.As(err, &neverReady) {
+		t.Fatalf("want *DependencyNeverReadyError, have %T", err)
+	}
+
+	if want, have := "db", neverReady.Provider; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestDependencyNeverReadyWaitAll(t *testing.T) {
+	g := deprun.New(deprun.WithWaitAll())
+	g.Phase(0).AddDepWith(func(deprun.ReadySignal) error { return nil }, func(error) {}, deprun.Name("config"))
+	g.Phase(1).Add(func() error { return nil }, func(error) {})
+
+	if err := g.Run(); !errors.Is(err, deprun.ErrDependencyNeverReady) {
+		t.Errorf("want %v, have %v", deprun.ErrDependencyNeverReady, err)
+	}
+}
+
+func TestDependencyNeverReadyWithoutDependents(t *testing.T) {
+	var g deprun.Group
+	g.AddDep(func(deprun.ReadySignal) error { return nil }, func(error) {})
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want no error, have %v", err)
+	}
+}
+
+func TestDependencyNeverReadyInterrupted(t *testing.T) {
+	var g deprun.Group
+	stop := make(chan struct{})
+	db := g.AddDep(func(deprun.ReadySignal) error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+	g.Add(func() error { return nil }, func(error) {}, db)
+
+	myError := errors.New("boom")
+	g.Add(func() error { return myError }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestNeverStarted(t *testing.T) {
+	var g deprun.Group
+	db := g.AddDepWith(func(deprun.ReadySignal) error {
+		return errors.New("no connection")
+	}, func(error) {}, deprun.NonCritical())
+
+	var interrupted error
+	g.AddWith(func() error {
+		t.Error("dependent started")
+		return nil
+	}, func(err error) { interrupted = err }, db, deprun.Name("api"))
+
+	err := g.Run()
+	if !errors.Is(err, deprun.ErrNeverStarted) {
+		t.Fatalf("want %v, have %v", deprun.ErrNeverStarted, err)
+	}
+
+	if want, have := "deprun: api: never started", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if want, have := err, interrupted; want != have {
+		t.Errorf("interrupt: want %v, have %v", want, have)
+	}
+}
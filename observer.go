@@ -0,0 +1,129 @@
+package deprun
+
+// ActorInfo identifies an actor in Observer callbacks, and in the contexts
+// the group passes to actors, see ActorFromContext.
+type ActorInfo struct {
+	Index int      // the registration index, as in States
+	Name  string   // see Name; the registration index, e.g. "#3", if unnamed
+	Tags  []string // see Tags
+	Group string   // see WithGroupName
+
+	Meta map[string]string // see Meta; must not be modified
+}
+
+// Observer is notified of the lifecycle of a group and its actors, e.g. to
+// log, record metrics or alert in one place instead of wrapping every actor.
+// Callbacks of different actors may be invoked concurrently; they should
+// return quickly, as they run on the goroutines of the group. Embed
+// NopObserver to implement only some of the methods.
+type Observer interface {
+	// OnGroupStart is called when Run starts, with every actor of the
+	// group in registration order and the clock of the group, see
+	// WithClock, for observers that measure time. If a BeforeRun hook
+	// fails, it is called without actors, and OnGroupDone follows.
+	OnGroupStart(actors []ActorInfo, clock Clock)
+
+	// OnActorStart is called when an actor starts, after its dependencies
+	// are ready. Actors that never start are not reported.
+	OnActorStart(actor ActorInfo)
+
+	// OnActorReady is called when the dependency provided by an actor
+	// becomes ready: when an AddDep actor signals ready, when an Add actor
+	// starts, or when a task completes.
+	OnActorReady(actor ActorInfo)
+
+	// OnActorExit is called when a started actor returns, with its error
+	// after error filtering. NonCritical actors are reported too.
+	OnActorExit(actor ActorInfo, err error)
+
+	// OnInterrupt is called for every actor when the group is torn down,
+	// with the error passed to its interrupt function.
+	OnInterrupt(actor ActorInfo, err error)
+
+	// OnGroupDone is called when Run returns, with the error it returns.
+	OnGroupDone(err error)
+}
+
+// RestartObserver is an Observer also notified when an actor runs again
+// within a run: a task retried with Retry, or an actor restarted with
+// RestartSubtree, IdleTimeout or AddHeartbeat. Observers are checked for it
+// when notified; NopObserver implements it.
+type RestartObserver interface {
+	Observer
+
+	// OnActorRestart is called before an actor runs again, with the error
+	// its previous execution returned, or the error it was stopped with.
+	OnActorRestart(actor ActorInfo, err error)
+}
+
+// NopObserver is an Observer that does nothing. Embed it in an Observer
+// implementation to only override the callbacks of interest.
+type NopObserver struct{}
+
+func (NopObserver) OnGroupStart([]ActorInfo, Clock) {}
+func (NopObserver) OnActorStart(ActorInfo)          {}
+func (NopObserver) OnActorReady(ActorInfo)          {}
+func (NopObserver) OnActorExit(ActorInfo, error)    {}
+func (NopObserver) OnActorRestart(ActorInfo, error) {}
+func (NopObserver) OnInterrupt(ActorInfo, error)    {}
+func (NopObserver) OnGroupDone(error)               {}
+
+// WithObserver registers o to be notified of the lifecycle of the group. It
+// may be given several times; observers are notified in order.
+func WithObserver(o Observer) Option {
+	return func(g *Group) {
+		g.observers = append(g.observers, o)
+	}
+}
+
+// observers notifies every Observer it holds.
+type observers []Observer
+
+func (os observers) OnGroupStart(actors []ActorInfo, clock Clock) {
+	for _, o := range os {
+		o.OnGroupStart(actors, clock)
+	}
+}
+
+func (os observers) OnActorStart(actor ActorInfo) {
+	for _, o := range os {
+		o.OnActorStart(actor)
+	}
+}
+
+func (os observers) OnActorReady(actor ActorInfo) {
+	for _, o := range os {
+		o.OnActorReady(actor)
+	}
+}
+
+func (os observers) OnActorExit(actor ActorInfo, err error) {
+	for _, o := range os {
+		o.OnActorExit(actor, err)
+	}
+}
+
+func (os observers) OnActorRestart(actor ActorInfo, err error) {
+	for _, o := range os {
+		if r, ok := o.(RestartObserver); ok {
+			r.OnActorRestart(actor, err)
+		}
+	}
+}
+
+func (os observers) OnInterrupt(actor ActorInfo, err error) {
+	for _, o := range os {
+		o.OnInterrupt(actor, err)
+	}
+}
+
+func (os observers) OnGroupDone(err error) {
+	for _, o := range os {
+		o.OnGroupDone(err)
+	}
+}
+
+// info returns the ActorInfo describing a.
+func (a *actor) info() ActorInfo {
+	return ActorInfo{Index: a.index, Name: a.String(), Tags: a.tags, Group: a.group, Meta: a.meta}
+}
@@ -0,0 +1,129 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+type recorder struct {
+	deprun.NopObserver
+
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) OnGroupStart(actors []deprun.ActorInfo, _ deprun.Clock) {
+	var names []string
+	for _, a := range actors {
+		names = append(names, a.Name)
+	}
+	r.record("group start: %s", strings.Join(names, ", "))
+}
+func (r *recorder) OnActorStart(a deprun.ActorInfo)           { r.record("start %s", a.Name) }
+func (r *recorder) OnActorReady(a deprun.ActorInfo)           { r.record("ready %s", a.Name) }
+func (r *recorder) OnActorExit(a deprun.ActorInfo, err error) { r.record("exit %s: %v", a.Name, err) }
+func (r *recorder) OnInterrupt(a deprun.ActorInfo, err error) {
+	r.record("interrupt %s: %v", a.Name, err)
+}
+func (r *recorder) OnGroupDone(err error) { r.record("done: %v", err) }
+func (r *recorder) OnActorRestart(a deprun.ActorInfo, err error) {
+	r.record("restart %s: %v", a.Name, err)
+}
+
+func TestObserver(t *testing.T) {
+	var r recorder
+	g := deprun.New(deprun.WithObserver(&r))
+
+	stop := make(chan struct{})
+	dep := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	myError := errors.New("boom")
+	g.AddWith(func() error { return myError }, func(error) {}, dep, deprun.Name("api"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	for _, want := range []string{
+		"start db", "ready db", "start api", "ready api", "exit api: boom",
+		"interrupt db: boom", "interrupt api: boom", "exit db: <nil>", "done: boom",
+	} {
+		if !slices.Contains(r.events, want) {
+			t.Errorf("missing event %q in %q", want, r.events)
+		}
+	}
+
+	if want, have := "group start: db, api", r.events[0]; want != have {
+		t.Errorf("want first event %q, have %q", want, have)
+	}
+
+	if want, have := "done: boom", r.events[len(r.events)-1]; want != have {
+		t.Errorf("want last event %q, have %q", want, have)
+	}
+
+	if index := slices.Index(r.events, "ready db"); index > slices.Index(r.events, "start api") {
+		t.Errorf("api started before db was ready: %q", r.events)
+	}
+}
+
+func TestObserverSkipsUnstarted(t *testing.T) {
+	var r recorder
+	g := deprun.New(deprun.WithObserver(&r))
+
+	myError := errors.New("boom")
+	dep := g.AddDepWith(func(deprun.ReadySignal) error { return myError }, func(error) {}, deprun.Name("db"))
+	g.AddWith(func() error { return nil }, func(error) {}, dep, deprun.Name("api"))
+
+	g.Run()
+
+	if slices.Contains(r.events, "start api") {
+		t.Errorf("unexpected start of api in %q", r.events)
+	}
+}
+
+func TestRestartObserver(t *testing.T) {
+	var r recorder
+	g := deprun.New(deprun.WithObserver(&r))
+
+	var attempts int
+	flaky := errors.New("flaky")
+	g.AddTask(func(context.Context) error {
+		if attempts++; attempts < 3 {
+			return flaky
+		}
+		return nil
+	}, deprun.Name("job"), deprun.Retry(deprun.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	var restarts []string
+	for _, e := range r.events {
+		if strings.HasPrefix(e, "restart") {
+			restarts = append(restarts, e)
+		}
+	}
+
+	if want, have := []string{"restart job: flaky", "restart job: flaky"}, restarts; !slices.Equal(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
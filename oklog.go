@@ -0,0 +1,38 @@
+package deprun
+
+// Runner is the API of oklog/run.Group, which *run.Group implements.
+// Registration code written against it works with both libraries.
+type Runner interface {
+	Add(execute func() error, interrupt func(error))
+	Run() error
+}
+
+// Oklog returns a Runner adding actors to g, for code written against
+// oklog/run.Group. Migrating such code only takes changing its parameter from
+// *run.Group to Runner:
+//
+//	func registerHTTP(g deprun.Runner) { g.Add(serve, stop) }
+//
+//	registerHTTP(deprun.Oklog(&g, db, deprun.NonCritical()))
+//
+// opts, typically dependencies, are applied to every actor added through
+// the Runner. Run runs g.
+//
+// An existing *run.Group cannot be converted, since oklog/run does not
+// expose its actors; the code registering them has to take a Runner.
+func Oklog(g *Group, opts ...ActorOption) Runner {
+	return oklogRunner{g, opts}
+}
+
+type oklogRunner struct {
+	g    *Group
+	opts []ActorOption
+}
+
+func (r oklogRunner) Add(execute func() error, interrupt func(error)) {
+	r.g.AddWith(execute, interrupt, r.opts...)
+}
+
+func (r oklogRunner) Run() error {
+	return r.g.Run()
+}
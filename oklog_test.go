@@ -0,0 +1,37 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestOklog(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	myError := errors.New("foobar")
+	register := func(r deprun.Runner) {
+		r.Add(func() error {
+			if db.State() != deprun.DependencyReady {
+				return errors.New("started before db")
+			}
+
+			return myError
+		}, nil)
+	}
+
+	r := deprun.Oklog(&g, db)
+	register(r)
+
+	if want, have := myError, r.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
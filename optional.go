@@ -0,0 +1,69 @@
+package deprun
+
+import "slices"
+
+// Optional makes an actor use d without depending on it: the actor starts
+// whether or not d is ready, e.g. to keep serving while a cache is down.
+// Once the actor has started, onResolved, if not nil, is called with the
+// state of d and its error when d becomes ready, fails or is interrupted,
+// or at once if it already is; it is not called on teardown. State and
+// Available report the state of d at any time. d may be an external
+// dependency, such as When or Probe, and starting the actor demands it, so
+// a Lazy provider of d starts then.
+func Optional(d *Dependency, onResolved func(state DependencyState, err error)) ActorOption {
+	return actorOption(func(a *actor) {
+		a.optional = append(a.optional, optionalDep{d, onResolved})
+	})
+}
+
+// optionalDep is a dependency of an actor added with Optional.
+type optionalDep struct {
+	d          *Dependency
+	onResolved func(DependencyState, error)
+}
+
+// uses returns the dependencies of a, required and optional, e.g. to find
+// the external dependencies a run has to resolve.
+func (a *actor) uses() []*Dependency {
+	if len(a.optional) == 0 {
+		return a.dependsOn
+	}
+
+	deps := slices.Clone(a.dependsOn)
+	for _, o := range a.optional {
+		deps = append(deps, o.d)
+	}
+
+	return deps
+}
+
+// watchOptional demands the optional dependencies of a, so that Lazy
+// providers start, and calls their callbacks as they resolve, until stop is
+// closed.
+func (a *actor) watchOptional(stop <-chan struct{}) {
+	for _, o := range a.optional {
+		if o.d == nil {
+			continue
+		}
+
+		o.d.Demand()
+		if o.onResolved == nil {
+			continue
+		}
+
+		go func() {
+			select {
+			case <-o.d.Done():
+			case <-stop:
+				return
+			}
+
+			// Teardown interrupts the dependencies that are not resolved.
+			select {
+			case <-stop:
+			default:
+				o.onResolved(o.d.State(), o.d.Err())
+			}
+		}()
+	}
+}
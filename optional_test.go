@@ -0,0 +1,92 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestOptional(t *testing.T) {
+	var g deprun.Group
+
+	cacheDown := errors.New("cache down")
+	fail := make(chan struct{})
+	cache := g.AddDepWith(func(deprun.ReadySignal) error {
+		<-fail
+		return cacheDown
+	}, func(error) {}, deprun.NonCritical())
+
+	type resolution struct {
+		state deprun.DependencyState
+		err   error
+	}
+
+	resolved := make(chan resolution, 1)
+	myError := errors.New("done")
+	g.AddWith(func() error {
+		// Started although the cache is not ready.
+		if want, have := deprun.DependencyPending, cache.State(); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+
+		close(fail)
+
+		if want, have := (resolution{deprun.DependencyFailed, cacheDown}), <-resolved; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+
+		return myError
+	}, func(error) {}, deprun.Optional(cache, func(state deprun.DependencyState, err error) {
+		resolved <- resolution{state, err}
+	}))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestOptionalExternalAndLazy(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	cache := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Lazy(), deprun.Name("cache"))
+
+	flag := deprun.When(func() bool { return true }, time.Millisecond)
+
+	resolved := make(chan string, 2)
+	report := func(name string) func(deprun.DependencyState, error) {
+		return func(state deprun.DependencyState, _ error) {
+			if state == deprun.DependencyReady {
+				resolved <- name
+			}
+		}
+	}
+
+	myError := errors.New("done")
+	g.AddWith(func() error {
+		have := map[string]bool{<-resolved: true, <-resolved: true}
+		if !have["cache"] || !have["flag"] {
+			t.Errorf("want cache and flag resolved, have %v", have)
+		}
+
+		return myError
+	}, func(error) {}, deprun.Optional(cache, report("cache")), deprun.Optional(flag, report("flag")))
+
+	done := make(chan error, 1)
+	go func() { done <- g.Run() }()
+
+	select {
+	case err := <-done:
+		if want, have := myError, err; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("optional dependencies never resolved")
+	}
+}
@@ -0,0 +1,82 @@
+package deprun
+
+// ActorOption configures an actor when it is added to a Group. A
+// *Dependency is an ActorOption: passing one makes the actor depend on it.
+type ActorOption interface {
+	applyActor(a *actor)
+}
+
+// actorOption implements ActorOption.
+type actorOption func(a *actor)
+
+func (o actorOption) applyActor(a *actor) { o(a) }
+
+func (s *Dependency) applyActor(a *actor) {
+	a.dependsOn = append(a.dependsOn, s)
+}
+
+// DependsOn makes an actor depend on deps, like the dependencies passed to
+// Add. It lets AddWith and the other methods taking actor options mix
+// dependencies with options:
+//
+//	g.AddWith(execute, interrupt, deprun.DependsOn(deps...), deprun.Name("api"))
+func DependsOn(deps ...*Dependency) ActorOption {
+	return actorOption(func(a *actor) {
+		a.dependsOn = append(a.dependsOn, deps...)
+	})
+}
+
+// Name sets the name of an actor, used to identify it in errors and
+// diagnostics. Unnamed actors are identified by their registration index,
+// e.g. "#3".
+func Name(name string) ActorOption {
+	return actorOption(func(a *actor) {
+		a.name = name
+	})
+}
+
+// Option configures a Group, see New.
+type Option func(*Group)
+
+// New returns a Group configured with opts. The zero value of a Group is
+// equivalent to New() and remains useful.
+func New(opts ...Option) *Group {
+	g := &Group{}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// WithStartLimit bounds how many actors may be starting at the same time
+// once their dependencies are ready. An actor added with AddDep is starting
+// until it signals ready or returns; an actor added with Add only while it
+// is being launched. Actors wait for a free slot in order of Priority.
+// A limit of zero or less means no limit.
+func WithStartLimit(n int) Option {
+	return func(g *Group) {
+		g.startLimit = n
+	}
+}
+
+// NonCritical marks an actor whose exit, even with an error, does not tear
+// down the group; the remaining actors keep running. Its interrupt function
+// is still called on teardown. Actors depending on a non-critical actor that
+// exits before it is ready never start; they tear down the group with an
+// error matching ErrNeverStarted unless they are non-critical too.
+func NonCritical() ActorOption {
+	return actorOption(func(a *actor) {
+		a.nonCritical = true
+	})
+}
+
+// WithWaitAll makes actors that return nil simply finish, without tearing
+// down the group. The group runs until every actor has returned, or until an
+// actor returns an error, which tears it down as usual. This suits batch
+// workloads built with the same dependency machinery.
+func WithWaitAll() Option {
+	return func(g *Group) {
+		g.waitAll = true
+	}
+}
@@ -0,0 +1,191 @@
+// Package otelrun traces deprun groups with OpenTelemetry.
+//
+// A traced group records one span covering Run, a child span covering its
+// startup, until every actor is ready, and a child span per actor covering
+// both its dependency wait and its execution:
+//
+//	g := deprun.New(otelrun.WithTracing(otel.GetTracerProvider()))
+package otelrun
+
+import (
+	"context"
+	"sync"
+
+	"github.com/istovpets/deprun"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "github.com/istovpets/deprun/otelrun"
+
+// Attribute keys set on actor spans and events.
+const (
+	ActorKey   = attribute.Key("deprun.actor")
+	StartedKey = attribute.Key("deprun.actor.started")
+	CauseKey   = attribute.Key("deprun.teardown.cause")
+)
+
+// WithTracing traces the group with spans created by tp. A nil tp means the
+// global TracerProvider.
+//
+// Actor spans get a "started" event once the actor's dependencies are ready,
+// a "ready" event once it is ready and an "interrupt" event on teardown; the
+// span ends when the actor returns, with an error status if it failed. The
+// teardown cause is recorded as a "teardown" event on the group span.
+func WithTracing(tp trace.TracerProvider) deprun.Option {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	return deprun.WithObserver(&observer{tracer: tp.Tracer(instrumentation)})
+}
+
+// observer is the deprun.Observer installed by WithTracing.
+type observer struct {
+	deprun.NopObserver
+
+	tracer trace.Tracer
+
+	mu          sync.Mutex
+	clock       deprun.Clock
+	group       trace.Span
+	startup     trace.Span
+	actors      map[int]trace.Span // by ActorInfo.Index, names may repeat
+	pending     map[int]bool       // actors that are not ready yet
+	tearingDown bool
+}
+
+func (o *observer) OnGroupStart(actors []deprun.ActorInfo, clock deprun.Clock) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.clock = clock
+	now := trace.WithTimestamp(clock.Now())
+
+	var ctx context.Context
+	ctx, o.group = o.tracer.Start(context.Background(), "deprun.Run", now)
+	_, o.startup = o.tracer.Start(ctx, "deprun.startup", now)
+
+	o.actors = make(map[int]trace.Span, len(actors))
+	o.pending = make(map[int]bool, len(actors))
+	o.tearingDown = false
+
+	for _, a := range actors {
+		_, o.actors[a.Index] = o.tracer.Start(ctx, a.Name, now, trace.WithAttributes(ActorKey.String(a.Name)))
+		o.pending[a.Index] = true
+	}
+
+	o.endStartup()
+}
+
+func (o *observer) OnActorStart(actor deprun.ActorInfo) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if span, ok := o.actors[actor.Index]; ok {
+		span.AddEvent("started", o.now())
+	}
+}
+
+func (o *observer) OnActorReady(actor deprun.ActorInfo) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if span, ok := o.actors[actor.Index]; ok {
+		span.AddEvent("ready", o.now())
+	}
+
+	delete(o.pending, actor.Index)
+	o.endStartup()
+}
+
+func (o *observer) OnActorExit(actor deprun.ActorInfo, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	span, ok := o.actors[actor.Index]
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(StartedKey.Bool(true))
+	o.end(span, err)
+	delete(o.actors, actor.Index)
+}
+
+func (o *observer) OnInterrupt(actor deprun.ActorInfo, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.tearingDown {
+		o.tearingDown = true
+		o.group.AddEvent("teardown", o.now(), trace.WithAttributes(CauseKey.String(cause(err))))
+
+		if o.startup != nil {
+			o.startup.SetStatus(codes.Error, "teardown before the group was ready")
+			o.startup.End(o.now())
+			o.startup = nil
+		}
+	}
+
+	if span, ok := o.actors[actor.Index]; ok {
+		span.AddEvent("interrupt", o.now())
+	}
+}
+
+func (o *observer) OnGroupDone(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// Actors whose dependencies never became ready.
+	for _, span := range o.actors {
+		span.SetAttributes(StartedKey.Bool(false))
+		span.End(o.now())
+	}
+
+	o.actors = nil
+
+	if o.startup != nil {
+		o.startup.End(o.now())
+		o.startup = nil
+	}
+
+	if o.group != nil {
+		o.end(o.group, err)
+		o.group = nil
+	}
+}
+
+// endStartup ends the startup span once every actor is ready.
+func (o *observer) endStartup() {
+	if o.startup != nil && len(o.pending) == 0 {
+		o.startup.End(o.now())
+		o.startup = nil
+	}
+}
+
+// now returns the current time of the group as a span option.
+func (o *observer) now() trace.SpanEventOption {
+	return trace.WithTimestamp(o.clock.Now())
+}
+
+// end ends span, recording err if it is not nil.
+func (o *observer) end(span trace.Span, err error) {
+	now := o.now()
+	if err != nil {
+		span.RecordError(err, now)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End(now)
+}
+
+func cause(err error) string {
+	if err == nil {
+		return "all actors finished"
+	}
+
+	return err.Error()
+}
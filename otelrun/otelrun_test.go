@@ -0,0 +1,172 @@
+package otelrun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+	"github.com/istovpets/deprun/otelrun"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	g := deprun.New(otelrun.WithTracing(tp))
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	myError := errors.New("boom")
+	g.AddWith(func() error { return myError }, func(error) {}, db, deprun.Name("api"))
+
+	never := g.AddDepWith(func(deprun.ReadySignal) error { <-stop; return nil }, func(error) {}, deprun.Name("cache"))
+	g.AddWith(func() error { return nil }, func(error) {}, never, deprun.Name("worker"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+
+	for _, name := range []string{"deprun.Run", "deprun.startup", "db", "api", "cache", "worker"} {
+		if _, ok := spans[name]; !ok {
+			t.Fatalf("missing span %q", name)
+		}
+	}
+
+	root := spans["deprun.Run"]
+	if want, have := codes.Error, root.Status().Code; want != have {
+		t.Errorf("group status: want %v, have %v", want, have)
+	}
+
+	var teardown bool
+	for _, e := range root.Events() {
+		if e.Name == "teardown" {
+			teardown = true
+		}
+	}
+	if !teardown {
+		t.Error("missing teardown event")
+	}
+
+	for _, name := range []string{"deprun.startup", "db", "api", "worker"} {
+		if want, have := root.SpanContext().SpanID(), spans[name].Parent().SpanID(); want != have {
+			t.Errorf("%s: not a child of the group span", name)
+		}
+	}
+
+	if want, have := codes.Error, spans["deprun.startup"].Status().Code; want != have {
+		t.Errorf("startup status: want %v, have %v", want, have)
+	}
+
+	if want, have := codes.Error, spans["api"].Status().Code; want != have {
+		t.Errorf("api status: want %v, have %v", want, have)
+	}
+
+	for _, a := range spans["worker"].Attributes() {
+		if a.Key == otelrun.StartedKey && a.Value.AsBool() {
+			t.Error("worker: want not started")
+		}
+	}
+}
+
+func TestWithTracingStartup(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	g := deprun.New(otelrun.WithTracing(tp), deprun.WithWaitAll())
+
+	g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		return nil
+	}, func(error) {}, deprun.Name("config"))
+
+	if err := g.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, s := range recorder.Ended() {
+		if s.Name() == "deprun.startup" && s.Status().Code == codes.Error {
+			t.Errorf("startup: want ok status, have %v", s.Status())
+		}
+	}
+}
+
+// fixedClock is a deprun.Clock stopped at a fixed time. Its timers never
+// fire.
+type fixedClock struct{ now time.Time }
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func (c fixedClock) NewTimer(time.Duration) deprun.Timer { return stoppedTimer{} }
+
+func (c fixedClock) AfterFunc(time.Duration, func()) deprun.Timer { return stoppedTimer{} }
+
+type stoppedTimer struct{}
+
+func (stoppedTimer) C() <-chan time.Time      { return nil }
+func (stoppedTimer) Stop() bool               { return true }
+func (stoppedTimer) Reset(time.Duration) bool { return true }
+
+func TestWithTracingClock(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	now := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	g := deprun.New(otelrun.WithTracing(tp), deprun.WithClock(fixedClock{now}))
+
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("job"))
+
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range recorder.Ended() {
+		if !s.StartTime().Equal(now) || !s.EndTime().Equal(now) {
+			t.Errorf("%s: want start and end at %v, have %v and %v", s.Name(), now, s.StartTime(), s.EndTime())
+		}
+	}
+}
+
+func TestWithTracingSameName(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	g := deprun.New(otelrun.WithTracing(tp))
+
+	myError := errors.New("boom")
+	stop := make(chan struct{})
+	g.AddWith(func() error { <-stop; return nil }, func(error) { close(stop) }, deprun.Name("worker"))
+	g.AddWith(func() error { return myError }, func(error) {}, deprun.Name("worker"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	var ok, failed int
+	for _, s := range recorder.Ended() {
+		if s.Name() != "worker" {
+			continue
+		}
+		if s.Status().Code == codes.Error {
+			failed++
+		} else {
+			ok++
+		}
+	}
+
+	if want, have := 1, ok; want != have {
+		t.Errorf("ok spans: want %d, have %d", want, have)
+	}
+	if want, have := 1, failed; want != have {
+		t.Errorf("failed spans: want %d, have %d", want, have)
+	}
+}
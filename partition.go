@@ -0,0 +1,26 @@
+package deprun
+
+// Partition puts an actor in an isolation domain, e.g. one per tenant: the
+// exit of an actor of a partition that would tear the group down stops
+// only the actors of its partition, interrupted with its error, while the
+// other partitions and the core actors, those without a partition, keep
+// running. Core actors may be depended on by every partition; the exit of
+// a core actor tears down the whole group as usual.
+//
+// If every actor exited without tearing the group down, Run returns the
+// error of the first partition that failed.
+func Partition(name string) ActorOption {
+	return actorOption(func(a *actor) {
+		a.partition = name
+	})
+}
+
+// stopPartition stops the actors of the partition, see Partition.
+func (g *Group) stopPartition(actors []actor, partition string, err error) {
+	targets := make([]bool, len(actors))
+	for i := range actors {
+		targets[i] = !actors[i].hidden && actors[i].partition == partition
+	}
+
+	g.stopTargets(actors, targets, err)
+}
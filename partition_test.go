@@ -0,0 +1,82 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestPartition(t *testing.T) {
+	var g deprun.Group
+
+	blocking := func() (func() error, func(error), chan error) {
+		stop, reason := make(chan struct{}), make(chan error, 1)
+		return func() error {
+				<-stop
+				return nil
+			}, func(err error) {
+				reason <- err
+				close(stop)
+			}, reason
+	}
+
+	execute, interrupt, _ := blocking()
+	g.AddWith(execute, interrupt, deprun.Name("core"))
+
+	tenantErr := errors.New("tenant a failed")
+	failA := make(chan struct{})
+	g.AddWith(func() error {
+		<-failA
+		return tenantErr
+	}, func(error) {}, deprun.Partition("a"))
+
+	execute, interrupt, a := blocking()
+	g.AddWith(execute, interrupt, deprun.Partition("a"))
+
+	execute, interrupt, b := blocking()
+	g.AddWith(execute, interrupt, deprun.Partition("b"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		await(t, func() bool {
+			s := g.States()
+			return s[0].State == deprun.Ready && s[2].State == deprun.Ready && s[3].State == deprun.Ready
+		})
+		close(failA)
+
+		if want, have := tenantErr, <-a; want != have {
+			t.Errorf("partition a: want %v, have %v", want, have)
+		}
+
+		await(t, func() bool { return g.States()[2].State == deprun.Stopped })
+
+		// The core and partition b keep running.
+		for _, i := range []int{0, 3} {
+			if want, have := deprun.Ready, g.States()[i].State; want != have {
+				t.Errorf("%d: want %v, have %v", i, want, have)
+			}
+		}
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := myError, <-b; want != have {
+		t.Errorf("partition b: want %v, have %v", want, have)
+	}
+}
+
+func TestPartitionAllFailed(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("tenant failed")
+	g.AddWith(func() error { return myError }, func(error) {}, deprun.Partition("a"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
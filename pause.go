@@ -0,0 +1,89 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotPausable is returned by Pause and Resume when the running group has
+// no started actor of that name with a Pausable option.
+var ErrNotPausable = errors.New("actor not pausable")
+
+// Pausable lets Group.Pause and Group.Resume pause an actor without
+// stopping it, e.g. to stop a queue consumer from fetching during
+// maintenance while everything else keeps running. pause and resume are
+// called from the goroutine calling Pause or Resume; an error from either
+// leaves the actor as it was. A paused actor is still interrupted on
+// teardown, and must then return like any other.
+func Pausable(pause, resume func() error) ActorOption {
+	return actorOption(func(a *actor) {
+		a.pause, a.resume = pause, resume
+	})
+}
+
+// Pause pauses the started actor named name, see Pausable. Pausing a paused
+// actor does nothing. The actor is reported as paused by Snapshot and
+// String until it is resumed.
+func (g *Group) Pause(name string) error {
+	return g.setPaused(name, true)
+}
+
+// Resume resumes the actor named name, paused with Pause. Resuming an actor
+// that is not paused does nothing.
+func (g *Group) Resume(name string) error {
+	return g.setPaused(name, false)
+}
+
+func (g *Group) setPaused(name string, paused bool) error {
+	a := g.pausable(name)
+	if a == nil {
+		return fmt.Errorf("deprun: %s: %w", name, ErrNotPausable)
+	}
+
+	a.state.pauseMu.Lock()
+	defer a.state.pauseMu.Unlock()
+
+	if a.state.paused.Load() == paused {
+		return nil
+	}
+
+	hook := a.resume
+	if paused {
+		hook = a.pause
+	}
+
+	if err := hook(); err != nil {
+		return err
+	}
+
+	a.state.paused.Store(paused)
+
+	return nil
+}
+
+// pausable returns the started pausable actor named name of the running
+// group, or nil.
+func (g *Group) pausable(name string) *actor {
+	run := g.run.Load()
+	if run == nil || g.status.Load() != groupRunning {
+		return nil
+	}
+
+	for i := range *run {
+		a := &(*run)[i]
+		if a.pause == nil || a.String() != name {
+			continue
+		}
+
+		if s := a.currentState(); s == Running || s == Ready {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// paused reports whether a is paused, see Group.Pause.
+func (a *actor) paused() bool {
+	return a.state != nil && a.state.paused.Load()
+}
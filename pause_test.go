@@ -0,0 +1,107 @@
+package deprun_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestPause(t *testing.T) {
+	var (
+		g      deprun.Group
+		events []string
+	)
+
+	stop := make(chan struct{})
+	g.AddWith(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("consumer"), deprun.Pausable(func() error {
+		events = append(events, "pause")
+		return nil
+	}, func() error {
+		events = append(events, "resume")
+		return nil
+	}))
+
+	if err := g.Pause("consumer"); !errors.Is(err, deprun.ErrNotPausable) {
+		t.Errorf("idle group: want %v, have %v", deprun.ErrNotPausable, err)
+	}
+
+	myError := errors.New("done")
+	g.AddWith(func() error {
+		await(t, func() bool { return g.States()[0].State == deprun.Ready })
+
+		if err := g.Pause("api"); !errors.Is(err, deprun.ErrNotPausable) {
+			t.Errorf("want %v, have %v", deprun.ErrNotPausable, err)
+		}
+
+		for range 2 {
+			if err := g.Pause("consumer"); err != nil {
+				t.Error(err)
+			}
+		}
+
+		if want, have := true, g.Snapshot().Actors[0].Paused; want != have {
+			t.Errorf("want paused %v, have %v", want, have)
+		}
+		if want, have := "consumer: ready (paused)", g.String(); !strings.Contains(have, want) {
+			t.Errorf("want %q in %q", want, have)
+		}
+
+		if err := g.Resume("consumer"); err != nil {
+			t.Error(err)
+		}
+
+		if want, have := false, g.Snapshot().Actors[0].Paused; want != have {
+			t.Errorf("want paused %v, have %v", want, have)
+		}
+
+		return myError
+	}, func(error) {}, deprun.Name("api"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := "pause resume", strings.Join(events, " "); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestPauseError(t *testing.T) {
+	var g deprun.Group
+
+	pauseErr := errors.New("cannot pause")
+	stop := make(chan struct{})
+	g.AddWith(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("consumer"), deprun.Pausable(func() error {
+		return pauseErr
+	}, func() error {
+		t.Error("resumed an actor that is not paused")
+		return nil
+	}))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		await(t, func() bool { return g.States()[0].State == deprun.Ready })
+
+		if want, have := pauseErr, g.Pause("consumer"); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+
+		if err := g.Resume("consumer"); err != nil {
+			t.Error(err)
+		}
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
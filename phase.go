@@ -0,0 +1,91 @@
+package deprun
+
+import (
+	"context"
+	"slices"
+)
+
+// Phase is a startup phase of a Group, see Group.Phase.
+type Phase struct {
+	g *Group
+	n int
+}
+
+// Phase returns startup phase n of the group. Actors added to a phase only
+// start once every actor of the preceding phase is ready; actors added with
+// Add count as ready as soon as they start. Phases are ordered by number,
+// gaps are allowed, and actors added directly to the group belong to phase 0.
+//
+// Phases provide coarse ordering without wiring explicit dependencies.
+// Dependencies passed to an actor are honored in addition to its phase.
+func (g *Group) Phase(n int) Phase {
+	return Phase{g, n}
+}
+
+// AddDep adds an actor to the phase, like Group.AddDep.
+func (p Phase) AddDep(execute func(ready ReadySignal) error, interrupt func(error), dependsOn ...*Dependency) *Dependency {
+	return p.AddDepWith(execute, interrupt, DependsOn(dependsOn...))
+}
+
+// AddDepWith adds an actor to the phase, like Group.AddDepWith.
+func (p Phase) AddDepWith(execute func(ready ReadySignal) error, interrupt func(error), opts ...ActorOption) *Dependency {
+	return p.g.add(actor{execute: execute, interrupt: interrupt, provider: true, phase: p.n}, opts)
+}
+
+// Add adds an actor to the phase, like Group.Add.
+func (p Phase) Add(execute func() error, interrupt func(error), dependsOn ...*Dependency) {
+	p.AddWith(execute, interrupt, DependsOn(dependsOn...))
+}
+
+// AddWith adds an actor to the phase, like Group.AddWith.
+func (p Phase) AddWith(execute func() error, interrupt func(error), opts ...ActorOption) {
+	p.g.add(actor{execute: addExecute(execute), interrupt: interrupt, phase: p.n}, opts)
+}
+
+// phased returns a copy of actors where every actor additionally depends on
+// a barrier that becomes ready once the preceding phase is ready.
+func phased(actors []actor) []actor {
+	members := make(map[int][]*Dependency)
+	for _, a := range actors {
+		if !a.lazy {
+			members[a.phase] = append(members[a.phase], a.provides)
+		}
+	}
+
+	if len(members) < 2 {
+		return slices.Clone(actors)
+	}
+
+	phases := make([]int, 0, len(members))
+	for n := range members {
+		phases = append(phases, n)
+	}
+
+	slices.Sort(phases)
+
+	barriers := make(map[int]*Dependency, len(phases)-1)
+	for i, n := range phases[1:] {
+		barriers[n] = barrier(members[phases[i]])
+	}
+
+	result := make([]actor, len(actors))
+	for i, a := range actors {
+		if b, ok := barriers[a.phase]; ok {
+			a.dependsOn = append(slices.Clip(a.dependsOn), b)
+		}
+
+		result[i] = a
+	}
+
+	return result
+}
+
+// barrier returns an external Dependency that becomes ready once all deps
+// are ready.
+func barrier(deps []*Dependency) *Dependency {
+	return externalDependency(func(_ context.Context, ready ReadySignal) error {
+		ready()
+
+		return nil
+	}, deps...)
+}
@@ -0,0 +1,81 @@
+package deprun_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestPhases(t *testing.T) {
+	const runs = 50
+
+	for i := range runs {
+		var (
+			g     deprun.Group
+			mu    sync.Mutex
+			order []string
+		)
+		record := func(s string) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, s)
+		}
+		stop := make(chan struct{})
+		wait := func() error { <-stop; return nil }
+		interrupt := func(error) {}
+
+		myError := errors.New("done")
+		g.Phase(2).Add(func() error {
+			record("api")
+			return myError
+		}, func(error) { close(stop) })
+		g.Phase(1).AddDep(func(ready deprun.ReadySignal) error {
+			record("cache")
+			ready()
+			return wait()
+		}, interrupt)
+		g.AddDep(func(ready deprun.ReadySignal) error {
+			time.Sleep(time.Millisecond)
+			record("db")
+			ready()
+			return wait()
+		}, interrupt)
+		g.Add(func() error {
+			record("config")
+			return wait()
+		}, interrupt)
+
+		res := make(chan error, 1)
+		go func() { res <- g.Run() }()
+
+		select {
+		case err := <-res:
+			if err != myError {
+				t.Fatalf("run %d: want %v, have %v", i, myError, err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("run %d: deadlock", i)
+		}
+
+		if len(order) != 4 || order[2] != "cache" || order[3] != "api" {
+			t.Fatalf("run %d: unexpected start order %v", i, order)
+		}
+	}
+}
+
+func TestPhaseFailure(t *testing.T) {
+	var g deprun.Group
+	myError := errors.New("provider failed")
+	g.AddDep(func(deprun.ReadySignal) error { return myError }, func(error) {})
+	g.Phase(1).Add(func() error {
+		t.Error("phase 1 started although phase 0 never became ready")
+		return nil
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
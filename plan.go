@@ -0,0 +1,103 @@
+package deprun
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Plan is the startup plan of a group, see Group.Plan. It marshals to JSON.
+type Plan struct {
+	Steps []PlanStep `json:"steps"` // in startup order
+}
+
+// PlanStep describes how an actor would start.
+type PlanStep struct {
+	Name    string   `json:"name"`
+	Phase   int      `json:"phase"`              // see Group.Phase
+	Level   int      `json:"level"`              // see Group.Levels
+	Site    string   `json:"site,omitempty"`     // where it was registered
+	WaitsOn []string `json:"waits_on,omitempty"` // the actors it depends on
+
+	// External reports whether the actor also waits for something no actor
+	// of the group provides, e.g. a Probe.
+	External bool `json:"external,omitempty"`
+
+	Lazy     bool `json:"lazy,omitempty"`     // see Lazy
+	Disabled bool `json:"disabled,omitempty"` // see Enabled; it would not start
+
+	Meta map[string]string `json:"meta,omitempty"` // see Meta
+}
+
+// Plan returns how the group would start, without running anything: the
+// actors in startup order, with their phases and levels, and the actors
+// each of them would wait for. Enabled predicates are evaluated now. Its
+// String method renders it for review, e.g. of a wiring change.
+func (g *Group) Plan() Plan {
+	actors := g.graph()
+	disable(actors)
+
+	providers := providerIndices(actors)
+	levels := levels(actors)
+
+	steps := make([]PlanStep, len(actors))
+	for i := range actors {
+		a := &actors[i]
+		deps, external := a.edges(providers)
+		step := PlanStep{
+			Name:     a.String(),
+			Phase:    a.phase,
+			Level:    levels[i],
+			Site:     a.site,
+			External: external,
+			Lazy:     a.lazy,
+			Disabled: a.disabled,
+			Meta:     a.meta,
+		}
+
+		for _, dep := range deps {
+			step.WaitsOn = append(step.WaitsOn, actors[dep].String())
+		}
+
+		steps[i] = step
+	}
+
+	slices.SortStableFunc(steps, func(a, b PlanStep) int {
+		return cmp.Compare(a.Level, b.Level)
+	})
+
+	return Plan{Steps: steps}
+}
+
+// String renders the plan with a line per actor, in startup order, e.g.
+//
+//	level 1, phase 0: api <- db, cache, external
+func (p Plan) String() string {
+	var b strings.Builder
+	b.WriteString("deprun: startup plan\n")
+
+	for _, s := range p.Steps {
+		fmt.Fprintf(&b, "  level %d, phase %d: %s", s.Level, s.Phase, s.Name)
+
+		waits := s.WaitsOn
+		if s.External {
+			waits = append(slices.Clip(waits), "external")
+		}
+
+		if len(waits) > 0 {
+			fmt.Fprintf(&b, " <- %s", strings.Join(waits, ", "))
+		}
+
+		switch {
+		case s.Disabled:
+			b.WriteString(" (disabled)")
+		case s.Lazy:
+			b.WriteString(" (lazy)")
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
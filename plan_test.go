@@ -0,0 +1,49 @@
+package deprun_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestPlan(t *testing.T) {
+	var g deprun.Group
+
+	ready := func(ready deprun.ReadySignal) error {
+		ready()
+		return nil
+	}
+
+	db := g.AddDepWith(ready, func(error) {}, deprun.Name("db"))
+	cache := g.AddDepWith(ready, func(error) {}, deprun.Name("cache"), deprun.Lazy())
+	g.AddWith(func() error { return nil }, func(error) {}, db, cache,
+		deprun.Probe(func(context.Context) error { return nil }), deprun.Name("api"))
+	g.Phase(1).AddWith(func() error { return nil }, func(error) {}, deprun.Name("worker"))
+	g.AddWith(func() error { return nil }, func(error) {}, deprun.Name("debug"), deprun.Enabled(func() bool { return false }))
+
+	plan := g.Plan()
+
+	want := `deprun: startup plan
+  level 0, phase 0: db
+  level 0, phase 0: cache (lazy)
+  level 0, phase 0: debug (disabled)
+  level 1, phase 0: api <- db, cache, external
+  level 2, phase 1: worker
+`
+	if have := plan.String(); want != have {
+		t.Errorf("want\n%s\nhave\n%s", want, have)
+	}
+
+	api := plan.Steps[3]
+	api.Site = ""
+	buf, err := json.Marshal(api)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := `{"name":"api","phase":0,"level":1,"waits_on":["db","cache"],"external":true}`, string(buf); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
@@ -0,0 +1,126 @@
+package deprun
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Probe backoff and per-attempt timeout.
+const (
+	probeMinBackoff = 100 * time.Millisecond
+	probeMaxBackoff = 5 * time.Second
+	probeTimeout    = 5 * time.Second
+)
+
+// Probe returns a Dependency on an external system. Once the group runs,
+// check is called repeatedly, with exponential backoff between attempts,
+// until it returns nil; the dependency then becomes ready. Each attempt gets
+// a context with a timeout. Probing stops when the group is torn down.
+//
+// Probe dependencies need no actor of their own: pass them to Add or AddDep
+// like any other Dependency.
+func Probe(check func(ctx context.Context) error) *Dependency {
+	return externalDependency(func(ctx context.Context, ready ReadySignal) error {
+		clock, backoff := clockFrom(ctx), probeMinBackoff
+
+		for {
+			attempt, cancel := withTimeout(ctx, clock, probeTimeout)
+			err := check(attempt)
+			cancel()
+
+			if err == nil {
+				ready()
+
+				return nil
+			}
+
+			timer := clock.NewTimer(backoff)
+			select {
+			case <-timer.C():
+			case <-ctx.Done():
+				timer.Stop()
+
+				return ctx.Err()
+			}
+
+			backoff = min(2*backoff, probeMaxBackoff)
+		}
+	})
+}
+
+// When returns a Dependency that becomes ready once pred returns true, e.g.
+// once a file exists or a feature flag is enabled. Once the group runs, pred
+// is called right away and then every interval, until it returns true or
+// the group is torn down.
+func When(pred func() bool, interval time.Duration) *Dependency {
+	return externalDependency(func(ctx context.Context, ready ReadySignal) error {
+		timer := clockFrom(ctx).NewTimer(interval)
+		defer timer.Stop()
+
+		for !pred() {
+			select {
+			case <-timer.C():
+				timer.Reset(interval)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		ready()
+
+		return nil
+	})
+}
+
+// ProbeTCP returns a Dependency that becomes ready once a TCP connection to
+// addr can be established.
+func ProbeTCP(addr string) *Dependency {
+	return Probe(func(ctx context.Context) error {
+		var d net.Dialer
+
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	})
+}
+
+// ProbeHTTP returns a Dependency that becomes ready once a GET request to url
+// succeeds with a 2xx status code.
+func ProbeHTTP(url string) *Dependency {
+	return Probe(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unexpected status %s", resp.Status)
+		}
+
+		return nil
+	})
+}
+
+// Pinger is implemented by clients that can check their connection, such
+// as *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// ProbePing returns a Dependency that becomes ready once p.PingContext
+// succeeds.
+func ProbePing(p Pinger) *Dependency {
+	return Probe(p.PingContext)
+}
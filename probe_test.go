@@ -0,0 +1,116 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func runDependent(t *testing.T, dep *deprun.Dependency) error {
+	t.Helper()
+
+	var g deprun.Group
+	myError := errors.New("dependent started")
+	g.Add(func() error { return myError }, func(error) {}, dep)
+
+	res := make(chan error, 1)
+	go func() { res <- g.Run() }()
+
+	select {
+	case err := <-res:
+		if err != myError {
+			return err
+		}
+		return nil
+	case <-time.After(3 * time.Second):
+		t.Fatal("dependent did not start")
+		return nil
+	}
+}
+
+func TestProbe(t *testing.T) {
+	var attempts atomic.Int32
+	dep := deprun.Probe(func(context.Context) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	if err := runDependent(t, dep); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int32(3), attempts.Load(); want != have {
+		t.Errorf("attempts: want %d, have %d", want, have)
+	}
+}
+
+func TestProbeInterrupted(t *testing.T) {
+	var g deprun.Group
+	g.Add(func() error {
+		t.Error("dependent started")
+		return nil
+	}, func(error) {}, deprun.Probe(func(context.Context) error { return errors.New("never") }))
+	myError := errors.New("teardown")
+	g.Add(func() error { time.Sleep(10 * time.Millisecond); return myError }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestWhen(t *testing.T) {
+	var calls atomic.Int32
+	dep := deprun.When(func() bool {
+		return calls.Add(1) >= 3
+	}, time.Millisecond)
+	if err := runDependent(t, dep); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int32(3), calls.Load(); want != have {
+		t.Errorf("calls: want %d, have %d", want, have)
+	}
+}
+
+func TestProbeTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	if err := runDependent(t, deprun.ProbeTCP(ln.Addr().String())); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProbeHTTP(t *testing.T) {
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy.Swap(true) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	if err := runDependent(t, deprun.ProbeHTTP(srv.URL)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type pinger func(context.Context) error
+
+func (p pinger) PingContext(ctx context.Context) error { return p(ctx) }
+
+func TestProbePing(t *testing.T) {
+	dep := deprun.ProbePing(pinger(func(context.Context) error { return nil }))
+	if err := runDependent(t, dep); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,47 @@
+package deprun
+
+import "sync"
+
+// WithProgress calls onProgress each time an actor added with AddDep
+// becomes ready, with the number of such actors that are ready, their total
+// and the name of the actor that just became ready, e.g. to print
+// "starting 7/12: cache" during a long startup. Calls are serialized, in
+// the order the actors became ready.
+func WithProgress(onProgress func(ready, total int, lastReady string)) Option {
+	return func(g *Group) {
+		g.onProgress = onProgress
+	}
+}
+
+// progress counts the providers that became ready during a run.
+type progress struct {
+	mu    sync.Mutex
+	ready int
+	total int
+}
+
+// resetProgress starts counting the providers among actors.
+func (g *Group) resetProgress(actors []actor) {
+	g.progress.mu.Lock()
+	defer g.progress.mu.Unlock()
+
+	g.progress.ready, g.progress.total = 0, 0
+	for i := range actors {
+		if actors[i].awaited() && !actors[i].disabled {
+			g.progress.total++
+		}
+	}
+}
+
+// reportProgress reports that a became ready, if it is a provider.
+func (g *Group) reportProgress(a *actor) {
+	if g.onProgress == nil || !a.awaited() {
+		return
+	}
+
+	g.progress.mu.Lock()
+	defer g.progress.mu.Unlock()
+
+	g.progress.ready++
+	g.onProgress(g.progress.ready, g.progress.total, a.String())
+}
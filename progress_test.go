@@ -0,0 +1,40 @@
+package deprun_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestWithProgress(t *testing.T) {
+	var have []string
+	g := deprun.New(deprun.WithProgress(func(ready, total int, lastReady string) {
+		have = append(have, fmt.Sprintf("%d/%d: %s", ready, total, lastReady))
+	}))
+
+	stop := make(chan struct{})
+	provider := func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+
+		return nil
+	}
+
+	db := g.AddDepWith(provider, func(error) { close(stop) }, deprun.Name("db"))
+	cache := g.AddDepWith(provider, nil, db, deprun.Name("cache"))
+	g.AddDepWith(func(deprun.ReadySignal) error { <-stop; return nil }, nil, deprun.Name("never"))
+
+	myError := errors.New("done")
+	g.AddWith(func() error { return myError }, nil, cache, deprun.Name("api"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want := []string{"1/3: db", "2/3: cache"}; !slices.Equal(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,163 @@
+// Package promrun exposes runtime metrics of deprun groups to Prometheus.
+//
+//	c := promrun.NewCollector()
+//	prometheus.MustRegister(c)
+//	g := deprun.New(deprun.WithObserver(c))
+//
+// Metrics are keyed by actor name, see deprun.Name. To tell several groups
+// apart, register each Collector with a prometheus.WrapRegistererWith
+// registerer adding a distinguishing label.
+package promrun
+
+import (
+	"sync"
+	"time"
+
+	"github.com/istovpets/deprun"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus.Collector and a deprun.Observer recording the
+// lifecycle of a group. It exposes:
+//
+//   - deprun_actors: the number of actors in the group;
+//   - deprun_actor_running: 1 while an actor is running;
+//   - deprun_actor_ready: 1 while an actor is ready;
+//   - deprun_actor_restarts_total: how often an actor was restarted by a
+//     restart or retry policy;
+//   - deprun_actor_time_to_ready_seconds: the time from the start of Run
+//     until an actor became ready;
+//   - deprun_teardown_duration_seconds: the time from the start of the
+//     teardown until Run returned.
+type Collector struct {
+	deprun.NopObserver
+
+	actors      prometheus.Gauge
+	running     *prometheus.GaugeVec
+	ready       *prometheus.GaugeVec
+	restarts    *prometheus.CounterVec
+	timeToReady *prometheus.HistogramVec
+	teardown    prometheus.Histogram
+
+	mu       sync.Mutex
+	clock    deprun.Clock
+	start    time.Time
+	stopping time.Time
+}
+
+// NewCollector returns a Collector, to be registered both with a
+// prometheus.Registerer and, with deprun.WithObserver, with a group.
+func NewCollector() *Collector {
+	return &Collector{
+		actors: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "deprun_actors",
+			Help: "Number of actors in the group.",
+		}),
+		running: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "deprun_actor_running",
+			Help: "Whether the actor is running.",
+		}, []string{"actor"}),
+		ready: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "deprun_actor_ready",
+			Help: "Whether the actor is ready.",
+		}, []string{"actor"}),
+		restarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "deprun_actor_restarts_total",
+			Help: "Number of times the actor was restarted.",
+		}, []string{"actor"}),
+		timeToReady: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "deprun_actor_time_to_ready_seconds",
+			Help:    "Time from the start of the group until the actor became ready.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"actor"}),
+		teardown: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "deprun_teardown_duration_seconds",
+			Help:    "Time from the start of the teardown until the group stopped.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.actors, c.running, c.ready, c.restarts, c.timeToReady, c.teardown}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.collectors() {
+		m.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.collectors() {
+		m.Collect(ch)
+	}
+}
+
+// OnGroupStart implements deprun.Observer.
+func (c *Collector) OnGroupStart(actors []deprun.ActorInfo, clock deprun.Clock) {
+	c.mu.Lock()
+	c.clock = clock
+	c.start = clock.Now()
+	c.stopping = time.Time{}
+	c.mu.Unlock()
+
+	c.actors.Set(float64(len(actors)))
+
+	for _, a := range actors {
+		c.running.WithLabelValues(a.Name).Set(0)
+		c.ready.WithLabelValues(a.Name).Set(0)
+		c.restarts.WithLabelValues(a.Name)
+	}
+}
+
+// OnActorStart implements deprun.Observer.
+func (c *Collector) OnActorStart(actor deprun.ActorInfo) {
+	c.running.WithLabelValues(actor.Name).Set(1)
+}
+
+// OnActorRestart implements deprun.RestartObserver.
+func (c *Collector) OnActorRestart(actor deprun.ActorInfo, _ error) {
+	c.restarts.WithLabelValues(actor.Name).Inc()
+}
+
+// OnActorReady implements deprun.Observer.
+func (c *Collector) OnActorReady(actor deprun.ActorInfo) {
+	c.mu.Lock()
+	elapsed := c.clock.Now().Sub(c.start)
+	c.mu.Unlock()
+
+	c.ready.WithLabelValues(actor.Name).Set(1)
+	c.timeToReady.WithLabelValues(actor.Name).Observe(elapsed.Seconds())
+}
+
+// OnActorExit implements deprun.Observer.
+func (c *Collector) OnActorExit(actor deprun.ActorInfo, _ error) {
+	c.running.WithLabelValues(actor.Name).Set(0)
+	c.ready.WithLabelValues(actor.Name).Set(0)
+}
+
+// OnInterrupt implements deprun.Observer.
+func (c *Collector) OnInterrupt(deprun.ActorInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.clock != nil && c.stopping.IsZero() {
+		c.stopping = c.clock.Now()
+	}
+}
+
+// OnGroupDone implements deprun.Observer.
+func (c *Collector) OnGroupDone(error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A Collector never told of a group start has no clock.
+	if c.clock == nil || c.stopping.IsZero() {
+		return
+	}
+
+	c.teardown.Observe(c.clock.Now().Sub(c.stopping).Seconds())
+}
@@ -0,0 +1,139 @@
+package promrun_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+	"github.com/istovpets/deprun/promrun"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	c := promrun.NewCollector()
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+
+	g := deprun.New(deprun.WithObserver(c))
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	var running, ready float64
+	myError := errors.New("boom")
+	g.AddWith(func() error {
+		running = value(t, reg, "deprun_actor_running", "db")
+		ready = value(t, reg, "deprun_actor_ready", "db")
+		return myError
+	}, func(error) {}, db, deprun.Name("api"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if want, have := 1.0, running; want != have {
+		t.Errorf("db running: want %v, have %v", want, have)
+	}
+
+	if want, have := 1.0, ready; want != have {
+		t.Errorf("db ready: want %v, have %v", want, have)
+	}
+
+	if err := testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP deprun_actors Number of actors in the group.
+# TYPE deprun_actors gauge
+deprun_actors 2
+# HELP deprun_actor_running Whether the actor is running.
+# TYPE deprun_actor_running gauge
+deprun_actor_running{actor="api"} 0
+deprun_actor_running{actor="db"} 0
+# HELP deprun_actor_restarts_total Number of times the actor was restarted.
+# TYPE deprun_actor_restarts_total counter
+deprun_actor_restarts_total{actor="api"} 0
+deprun_actor_restarts_total{actor="db"} 0
+`), "deprun_actors", "deprun_actor_running", "deprun_actor_restarts_total"); err != nil {
+		t.Error(err)
+	}
+
+	if want, have := 2, testutil.CollectAndCount(c, "deprun_actor_time_to_ready_seconds"); want != have {
+		t.Errorf("time to ready series: want %d, have %d", want, have)
+	}
+
+	if want, have := 1, testutil.CollectAndCount(c, "deprun_teardown_duration_seconds"); want != have {
+		t.Errorf("teardown series: want %d, have %d", want, have)
+	}
+
+	if problems, err := testutil.CollectAndLint(c); err != nil || len(problems) > 0 {
+		t.Errorf("lint: %v %v", problems, err)
+	}
+}
+
+func TestCollectorRestarts(t *testing.T) {
+	c := promrun.NewCollector()
+	g := deprun.New(deprun.WithObserver(c))
+
+	var attempts int
+	g.AddTask(func(context.Context) error {
+		if attempts++; attempts < 3 {
+			return errors.New("flaky")
+		}
+
+		return nil
+	}, deprun.Name("job"), deprun.Retry(deprun.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+
+	if want, have := 2.0, value(t, reg, "deprun_actor_restarts_total", "job"); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+// value returns the value of the gauge or counter name of actor. It may be
+// called from actor goroutines, so it does not stop the test.
+func value(t *testing.T, g prometheus.Gatherer, name, actor string) float64 {
+	t.Helper()
+
+	families, err := g.Gather()
+	if err != nil {
+		t.Error(err)
+
+		return 0
+	}
+
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "actor" && l.GetValue() == actor {
+					return m.GetGauge().GetValue() + m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	t.Errorf("no %s for actor %q", name, actor)
+
+	return 0
+}
+
+func TestCollectorDoneWithoutStart(t *testing.T) {
+	c := promrun.NewCollector()
+	c.OnInterrupt(deprun.ActorInfo{}, nil)
+	c.OnGroupDone(nil)
+}
@@ -0,0 +1,63 @@
+package deprun
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// AtLeast returns a Dependency that becomes ready once k of deps are
+// ready, e.g. two connections out of three regions. If so many of deps fail
+// or are interrupted that k can no longer be reached, the group is torn
+// down with an error. Nil deps are ignored; AtLeast panics if k exceeds the
+// number of the others.
+func AtLeast(k int, deps ...*Dependency) *Dependency {
+	deps = slices.DeleteFunc(slices.Clone(deps), func(d *Dependency) bool { return d == nil })
+	if k > len(deps) {
+		panic(fmt.Sprintf("deprun: AtLeast(%d) of %d dependencies", k, len(deps)))
+	}
+
+	if k <= 0 {
+		return barrier(nil)
+	}
+
+	d := barrier(deps)
+	d.quorum = k
+
+	return d
+}
+
+// waitQuorum blocks until k of deps are ready. It fails once k can no
+// longer be reached, or when ctx is done.
+func waitQuorum(ctx context.Context, k int, deps []*Dependency) error {
+	resolved := make(chan bool, len(deps))
+	for _, dep := range deps {
+		go func() {
+			select {
+			case <-dep.Done():
+				resolved <- dep.isReady()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	var count, lost int
+	for count < k {
+		select {
+		case ok := <-resolved:
+			if ok {
+				count++
+
+				continue
+			}
+
+			if lost++; len(deps)-lost < k {
+				return fmt.Errorf("deprun: %d of %d dependencies can no longer be ready, need %d", lost, len(deps), k)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
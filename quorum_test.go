@@ -0,0 +1,74 @@
+package deprun_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestAtLeast(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	region := func(ok bool) *deprun.Dependency {
+		return g.AddDep(func(ready deprun.ReadySignal) error {
+			if ok {
+				ready()
+			}
+			<-stop
+
+			return nil
+		}, nil)
+	}
+
+	eu, us, ap := region(true), region(false), region(true)
+	g.Add(func() error { <-stop; return nil }, func(error) { close(stop) })
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, nil, deprun.AtLeast(2, eu, us, ap))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestAtLeastUnreachable(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	failing := func() *deprun.Dependency {
+		return g.AddDepWith(func(deprun.ReadySignal) error {
+			return errors.New("unreachable")
+		}, nil, deprun.NonCritical())
+	}
+
+	a, b := failing(), failing()
+	healthy := g.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) })
+
+	g.Add(func() error {
+		t.Error("dependent started")
+		return nil
+	}, nil, deprun.AtLeast(2, a, b, healthy))
+
+	err := g.Run()
+	if err == nil || !strings.Contains(err.Error(), "can no longer be ready") {
+		t.Errorf("want quorum error, have %v", err)
+	}
+}
+
+func TestAtLeastPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic")
+		}
+	}()
+
+	deprun.AtLeast(2, nil)
+}
@@ -0,0 +1,210 @@
+package deprun
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrDependencyUnready is passed to the interrupt functions of the
+// dependents of a re-armable dependency with the UnreadyInterrupt policy
+// when it stops being ready.
+var ErrDependencyUnready = errors.New("dependency no longer ready")
+
+// UnreadySignal is a function an actor added with AddRearmable calls to
+// signal that the dependency it provides is no longer ready. Calling its
+// ReadySignal again makes it ready again.
+type UnreadySignal func()
+
+// UnreadyPolicy decides what a re-armable dependency that stops being ready
+// does to its dependents, see AddRearmable.
+type UnreadyPolicy int
+
+// Unready policies.
+const (
+	// UnreadyBlock holds back the dependents that have not started yet
+	// until the dependency is ready again. Started dependents keep running.
+	UnreadyBlock UnreadyPolicy = iota
+
+	// UnreadyNotify only reports the change, see Dependency.Available and
+	// Dependency.Changed; dependents start as if the dependency were still
+	// ready.
+	UnreadyNotify
+
+	// UnreadyInterrupt is UnreadyBlock, and also interrupts the dependents
+	// that are running with ErrDependencyUnready. Like any actor exit, theirs
+	// tears the group down unless they are NonCritical.
+	UnreadyInterrupt
+)
+
+// rearm is the availability of a re-armable dependency once it is ready.
+type rearm struct {
+	policy UnreadyPolicy
+
+	mu       sync.Mutex
+	down     bool          // the provider called its UnreadySignal
+	stopped  bool          // the provider is gone, see Dependency.interrupt
+	changed  chan struct{} // closed and replaced when down or stopped change
+	watchers []func()      // called when the dependency goes down
+}
+
+// AddRearmable is like AddDep, except that the dependency it returns can
+// stop being ready: execute calls unready when the resource it provides
+// goes away, e.g. a lost connection, and ready once it is back. policy
+// decides what happens to the dependents meanwhile.
+//
+// The first call to ready resolves the dependency as usual; later changes
+// are reported by Dependency.Available and Dependency.Changed.
+func (g *Group) AddRearmable(execute func(ready ReadySignal, unready UnreadySignal) error, interrupt func(error), policy UnreadyPolicy, opts ...ActorOption) *Dependency {
+	d := g.add(actor{
+		rearmable: execute,
+		interrupt: interrupt,
+		provider:  true,
+	}, opts)
+	d.rearm = newRearm(policy)
+
+	return d
+}
+
+func newRearm(policy UnreadyPolicy) *rearm {
+	return &rearm{policy: policy, changed: make(chan struct{})}
+}
+
+// armExecute sets the execute function of an actor added with AddRearmable
+// for the dependency it provides.
+func (a *actor) armExecute() {
+	if a.rearmable == nil {
+		return
+	}
+
+	r, execute := a.provides.rearm, a.rearmable
+	a.execute = func(ready ReadySignal) error {
+		return execute(func() {
+			r.set(false)
+			ready()
+		}, func() {
+			r.set(true)
+		})
+	}
+}
+
+// Available reports whether the dependency is ready and, for a re-armable
+// dependency, has not become unready since.
+func (s *Dependency) Available() bool {
+	if !s.isReady() {
+		return false
+	}
+
+	if s.rearm == nil {
+		return true
+	}
+
+	s.rearm.mu.Lock()
+	defer s.rearm.mu.Unlock()
+
+	return !s.rearm.down && !s.rearm.stopped
+}
+
+// Changed returns a channel that is closed the next time Available may
+// change: when the dependency is resolved or, for a re-armable dependency,
+// when it becomes unready or ready again.
+func (s *Dependency) Changed() <-chan struct{} {
+	if s.rearm == nil || !s.resolved() {
+		return s.ch
+	}
+
+	s.rearm.mu.Lock()
+	defer s.rearm.mu.Unlock()
+
+	return s.rearm.changed
+}
+
+// set records whether the dependency is down, and notifies the watchers
+// when it goes down.
+func (r *rearm) set(down bool) {
+	r.mu.Lock()
+	if r.down == down || r.stopped {
+		r.mu.Unlock()
+
+		return
+	}
+
+	r.down = down
+	r.notify()
+	watchers := r.watchers
+	r.mu.Unlock()
+
+	if down {
+		for _, watch := range watchers {
+			watch()
+		}
+	}
+}
+
+// stop marks the provider as gone, releasing blocked dependents.
+func (r *rearm) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.stopped {
+		r.stopped = true
+		r.notify()
+	}
+}
+
+// notify wakes up whoever waits for a change. r.mu must be held.
+func (r *rearm) notify() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// waitAvailable blocks until the dependency is up and reports whether it
+// is, or false once the provider is gone.
+func (r *rearm) waitAvailable() bool {
+	for {
+		r.mu.Lock()
+		down, stopped, changed := r.down, r.stopped, r.changed
+		r.mu.Unlock()
+
+		switch {
+		case stopped:
+			return false
+		case !down:
+			return true
+		}
+
+		<-changed
+	}
+}
+
+// watchRearmable registers, for the current run, the interruption of the
+// running dependents of re-armable dependencies with the UnreadyInterrupt
+// policy.
+func (g *Group) watchRearmable(actors []actor) {
+	watchers := make(map[*rearm][]func())
+	for i := range actors {
+		a := &actors[i]
+		if a.hidden {
+			continue
+		}
+
+		for _, d := range a.dependsOn {
+			if d == nil || d.rearm == nil {
+				continue
+			}
+
+			watchers[d.rearm] = append(watchers[d.rearm], func() {
+				if s := a.currentState(); s == Running || s == Ready {
+					g.interruptActor(a, ErrDependencyUnready)
+				}
+			})
+		}
+	}
+
+	for r, w := range watchers {
+		if r.policy == UnreadyInterrupt {
+			r.mu.Lock()
+			r.watchers = w
+			r.mu.Unlock()
+		}
+	}
+}
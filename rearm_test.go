@@ -0,0 +1,152 @@
+package deprun_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestRearmableBlock(t *testing.T) {
+	var g deprun.Group
+
+	var (
+		up    atomic.Bool
+		down  = make(chan struct{})
+		stop  = make(chan struct{})
+		gated = make(chan struct{})
+	)
+
+	conn := g.AddRearmable(func(ready deprun.ReadySignal, unready deprun.UnreadySignal) error {
+		ready()
+		unready()
+		close(down)
+		time.Sleep(10 * time.Millisecond)
+		up.Store(true)
+		ready()
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) }, deprun.UnreadyBlock, deprun.Name("conn"))
+
+	gate := g.AddDep(func(ready deprun.ReadySignal) error {
+		<-down
+		ready()
+		<-gated
+
+		return nil
+	}, func(error) { close(gated) })
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		if !up.Load() {
+			return errors.New("started while conn was unready")
+		}
+
+		return myError
+	}, nil, conn, gate)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestRearmableInterrupt(t *testing.T) {
+	var g deprun.Group
+
+	var (
+		started  = make(chan struct{})
+		unready  = make(chan struct{})
+		stop     = make(chan struct{})
+		consumer = make(chan struct{})
+	)
+
+	conn := g.AddRearmable(func(ready deprun.ReadySignal, down deprun.UnreadySignal) error {
+		ready()
+		<-unready
+		down()
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) }, deprun.UnreadyInterrupt)
+
+	var interruptErr error
+	g.AddWith(func() error {
+		close(started)
+		<-consumer
+
+		return interruptErr
+	}, func(err error) {
+		interruptErr = err
+		close(consumer)
+	}, conn, deprun.NonCritical())
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		<-started
+		close(unready)
+		<-consumer
+
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := deprun.ErrDependencyUnready, interruptErr; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestRearmableNotify(t *testing.T) {
+	var g deprun.Group
+
+	var (
+		unready = make(chan struct{})
+		again   = make(chan struct{})
+		stop    = make(chan struct{})
+	)
+
+	conn := g.AddRearmable(func(ready deprun.ReadySignal, down deprun.UnreadySignal) error {
+		ready()
+		<-unready
+		down()
+		<-again
+		ready()
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) }, deprun.UnreadyNotify)
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		if !conn.Available() {
+			return errors.New("not available")
+		}
+
+		changed := conn.Changed()
+		close(unready)
+		<-changed
+
+		if conn.Available() {
+			return errors.New("still available")
+		}
+
+		changed = conn.Changed()
+		close(again)
+		<-changed
+
+		if !conn.Available() {
+			return errors.New("not available again")
+		}
+
+		return myError
+	}, nil, conn)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
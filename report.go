@@ -0,0 +1,118 @@
+package deprun
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Report describes a run of a group, see RunReport.
+type Report struct {
+	Start    time.Time // when Run started
+	Teardown time.Time // when the teardown began
+	End      time.Time // when Run returned
+
+	// Actors holds an entry per actor, in registration order.
+	Actors []ActorReport
+}
+
+// ActorReport describes the outcome of an actor in a Report. Times are zero
+// for steps the actor did not reach.
+type ActorReport struct {
+	Name    string
+	Started time.Time // zero if the actor never started
+	Ready   time.Time
+	Exited  time.Time
+	Err     error // the error returned by the actor, after error filtering
+
+	// AfterTeardown reports whether the actor returned after the teardown
+	// began, typically because it was interrupted.
+	AfterTeardown bool
+}
+
+// TimeToReady returns the time from the start of the actor until it was
+// ready, or zero if it never was.
+func (r ActorReport) TimeToReady() time.Duration {
+	if r.Ready.IsZero() {
+		return 0
+	}
+
+	return r.Ready.Sub(r.Started)
+}
+
+// RunReport is like Run, but also returns a Report on the outcome and
+// timings of every actor, e.g. for post-mortems.
+func (g *Group) RunReport() (Report, error) {
+	r := &reporter{g: g, clock: g.clockOrSystem(), index: make(map[int]int)}
+
+	g.update("RunReport called", func() {
+		g.observers = append(g.observers, r)
+	})
+
+	defer g.update("RunReport called", func() {
+		g.observers = slices.DeleteFunc(g.observers, func(o Observer) bool { return o == r })
+	})
+
+	err := g.Run()
+	r.report.End = r.clock.Now()
+
+	return r.report, err
+}
+
+// reporter is the Observer collecting a Report.
+type reporter struct {
+	NopObserver
+
+	g     *Group
+	clock Clock
+
+	mu     sync.Mutex
+	report Report
+	index  map[int]int // actor registration index to index in report.Actors
+}
+
+func (r *reporter) actor(actor ActorInfo, fn func(*ActorReport)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i, ok := r.index[actor.Index]; ok {
+		fn(&r.report.Actors[i])
+	}
+}
+
+func (r *reporter) OnGroupStart(actors []ActorInfo, _ Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.report.Start = r.clock.Now()
+	for _, a := range actors {
+		r.index[a.Index] = len(r.report.Actors)
+		r.report.Actors = append(r.report.Actors, ActorReport{Name: a.Name})
+	}
+}
+
+func (r *reporter) OnActorStart(actor ActorInfo) {
+	r.actor(actor, func(a *ActorReport) { a.Started = r.clock.Now() })
+}
+
+func (r *reporter) OnActorReady(actor ActorInfo) {
+	r.actor(actor, func(a *ActorReport) { a.Ready = r.clock.Now() })
+}
+
+func (r *reporter) OnActorExit(actor ActorInfo, err error) {
+	afterTeardown := r.g.status.Load() != groupRunning
+	r.actor(actor, func(a *ActorReport) {
+		a.Exited = r.clock.Now()
+		a.Err = err
+		a.AfterTeardown = afterTeardown
+	})
+}
+
+func (r *reporter) OnInterrupt(ActorInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.report.Teardown.IsZero() {
+		r.report.Teardown = r.clock.Now()
+	}
+}
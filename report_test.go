@@ -0,0 +1,95 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestRunReport(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		time.Sleep(10 * time.Millisecond)
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	myError := errors.New("boom")
+	g.AddWith(func() error { return myError }, func(error) {}, db, deprun.Name("api"))
+
+	never := g.AddDepWith(func(deprun.ReadySignal) error { <-stop; return nil }, func(error) {}, deprun.Name("cache"))
+	g.AddWith(func() error { return nil }, func(error) {}, never, deprun.Name("worker"))
+
+	report, err := g.RunReport()
+	if want, have := myError, err; want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if want, have := 4, len(report.Actors); want != have {
+		t.Fatalf("want %d actors, have %d", want, have)
+	}
+
+	dbReport, api, worker := report.Actors[0], report.Actors[1], report.Actors[3]
+	if want, have := "db", dbReport.Name; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if have := dbReport.TimeToReady(); have < 10*time.Millisecond {
+		t.Errorf("db: want time to ready of at least 10ms, have %v", have)
+	}
+
+	if !dbReport.AfterTeardown {
+		t.Error("db: want exit after teardown")
+	}
+
+	if want, have := myError, api.Err; want != have {
+		t.Errorf("api: want %v, have %v", want, have)
+	}
+
+	if api.AfterTeardown {
+		t.Error("api: want exit before teardown")
+	}
+
+	if api.Exited.After(report.Teardown) {
+		t.Errorf("api exited at %v, after teardown at %v", api.Exited, report.Teardown)
+	}
+
+	if !worker.Started.IsZero() || !worker.Exited.IsZero() {
+		t.Errorf("worker: want never started, have %+v", worker)
+	}
+
+	if report.End.Before(report.Start) {
+		t.Errorf("end %v before start %v", report.End, report.Start)
+	}
+}
+
+func TestRunReportSameName(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("boom")
+	stop := make(chan struct{})
+	g.AddWith(func() error { <-stop; return nil }, func(error) { close(stop) }, deprun.Name("worker"))
+	g.AddWith(func() error { return myError }, func(error) {}, deprun.Name("worker"))
+
+	report, err := g.RunReport()
+	if want, have := myError, err; want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if want, have := 2, len(report.Actors); want != have {
+		t.Fatalf("want %d actors, have %d", want, have)
+	}
+
+	if !report.Actors[0].AfterTeardown || report.Actors[0].Err != nil {
+		t.Errorf("first worker: want clean exit after teardown, have %+v", report.Actors[0])
+	}
+
+	if want, have := myError, report.Actors[1].Err; want != have {
+		t.Errorf("second worker: want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,199 @@
+package deprun
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// execution is one execution of an actor whose watchers may stop it and
+// run it again, see IdleTimeout, AddHeartbeat and RestartSubtree.
+type execution struct {
+	done      chan struct{}   // closed once the execution returned
+	interrupt func(err error) // interrupts the execution, at most once
+
+	mu      sync.Mutex
+	claimed bool // a watcher stopped the execution
+	over    bool // the execution returned
+	err     error
+	resume  func(stopped <-chan struct{}) bool
+}
+
+// claim reserves the stop of the execution to a watcher, unless it already
+// returned or another watcher claimed it; the watcher then interrupts it.
+// Once it returned, resume reports whether the actor runs again, or false
+// once stopped is closed. With a nil resume the actor exits with err.
+func (e *execution) claim(err error, resume func(stopped <-chan struct{}) bool) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.claimed || e.over {
+		return false
+	}
+
+	e.claimed, e.err, e.resume = true, err, resume
+
+	return true
+}
+
+// armRestarts makes the execute function of a run again each time a
+// watcher stopped it, or it failed with RestartSubtree, until the actor is
+// interrupted. Each execution is interrupted at most once. It must be
+// called after armExecute and before interruptOnce.
+func (g *Group) armRestarts(a *actor) {
+	var watchers []func(*execution)
+	if a.idleTimeout > 0 {
+		watchers = append(watchers, a.watchIdle)
+	}
+
+	if a.beating != nil {
+		watchers = append(watchers, g.armHeartbeat(a))
+	}
+
+	if len(a.restartDeps) > 0 {
+		watchers = append(watchers, a.watchDeps)
+	}
+
+	if len(watchers) == 0 && a.restart == nil {
+		return
+	}
+
+	var (
+		r                   = a.provides.rearm
+		execute             = a.execute
+		interrupt, shutdown = a.interrupt, a.shutdown
+		stopped             = make(chan struct{})
+		stop                sync.Once
+
+		mu      sync.Mutex
+		current = new(sync.Once) // interrupts the current execution
+	)
+
+	interruptCurrent := func(ctx context.Context, err error) {
+		mu.Lock()
+		once, interrupt := current, interrupt
+		mu.Unlock()
+
+		once.Do(func() {
+			if shutdown != nil {
+				shutdown(ctx, err)
+			} else {
+				interrupt(err)
+			}
+		})
+	}
+
+	a.interrupt = func(err error) {
+		stop.Do(func() { close(stopped) })
+		interruptCurrent(context.Background(), err)
+	}
+
+	if shutdown != nil {
+		a.shutdown = func(ctx context.Context, err error) {
+			stop.Do(func() { close(stopped) })
+			interruptCurrent(ctx, err)
+		}
+	}
+
+	a.execute = func(ready ReadySignal) error {
+		up := func() {
+			if r != nil {
+				r.set(false)
+			}
+			ready()
+		}
+
+		var failures int
+		for first := true; ; first = false {
+			// Teardown closes stopped before interrupting the current
+			// execution, so either it interrupts this one or it is seen here.
+			if !first {
+				mu.Lock()
+				current = new(sync.Once)
+				if a.ctxExecute != nil {
+					execute, interrupt = g.contextExecution(a)
+				}
+				mu.Unlock()
+			}
+
+			select {
+			case <-stopped:
+				return nil
+			default:
+			}
+
+			e := &execution{
+				done:      make(chan struct{}),
+				interrupt: func(err error) { interruptCurrent(context.Background(), err) },
+			}
+			for _, watch := range watchers {
+				go watch(e)
+			}
+
+			err := execute(up)
+			close(e.done)
+
+			e.mu.Lock()
+			e.over = true
+			claimed := e.claimed
+			e.mu.Unlock()
+
+			if !claimed {
+				if a.restart == nil || g.filterError(a, err) == nil {
+					return err
+				}
+
+				failures++
+				if failures >= a.restart.MaxAttempts {
+					return err
+				}
+
+				delay := a.restart.backoff(failures)
+				if a.breaker != nil {
+					delay = max(delay, a.breaker.failed(a.clock.Now()))
+				}
+
+				if !r.restartAfter(a.clock, delay, stopped) {
+					return err
+				}
+
+				g.observers.OnActorRestart(a.info(), err)
+
+				continue
+			}
+
+			if e.resume == nil {
+				return e.err
+			}
+
+			if !e.resume(stopped) {
+				return nil
+			}
+
+			g.observers.OnActorRestart(a.info(), e.err)
+		}
+	}
+}
+
+// awaitSilence blocks until last, in Unix nanoseconds, is at least d ago
+// and reports true, or reports false once done is closed.
+func awaitSilence(clock Clock, last *atomic.Int64, d time.Duration, done <-chan struct{}) bool {
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C():
+		case <-done:
+			return false
+		}
+
+		since := since(clock, time.Unix(0, last.Load()))
+		if since >= d {
+			return true
+		}
+
+		timer.Reset(d - since)
+	}
+}
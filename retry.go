@@ -0,0 +1,63 @@
+package deprun
+
+import "time"
+
+// RetryPolicy describes how a failed task is retried, see Retry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	// one. A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry; it doubles after every
+	// failed attempt, up to MaxBackoff. It defaults to 100ms.
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. It defaults to 5s.
+	MaxBackoff time.Duration
+}
+
+// Retry makes a task added with AddTask retry according to policy when it
+// returns an error, so that transient failures do not fail the task. Only
+// the error of the last attempt propagates; errors removed by the error
+// filters are not retried. Retrying stops when the group is torn down.
+// Retry has no effect on other actors.
+func Retry(policy RetryPolicy) ActorOption {
+	if policy.Backoff <= 0 {
+		policy.Backoff = probeMinBackoff
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = probeMaxBackoff
+	}
+
+	return actorOption(func(a *actor) {
+		a.retry = &policy
+	})
+}
+
+// execute runs a, retrying it if it is a task with a retry policy, and
+// returns its filtered error.
+func (g *Group) execute(a *actor, ready ReadySignal, stop <-chan struct{}) error {
+	err := g.filterError(a, a.execute(ready))
+	if a.retry == nil || !a.task {
+		return err
+	}
+
+	backoff := a.retry.Backoff
+	for attempt := 1; err != nil && attempt < a.retry.MaxAttempts; attempt++ {
+		timer := a.clock.NewTimer(backoff)
+		select {
+		case <-timer.C():
+		case <-stop:
+			timer.Stop()
+
+			return err
+		}
+
+		backoff = min(2*backoff, a.retry.MaxBackoff)
+		g.observers.OnActorRestart(a.info(), err)
+		err = g.filterError(a, a.execute(ready))
+	}
+
+	return err
+}
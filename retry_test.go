@@ -0,0 +1,83 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestRetry(t *testing.T) {
+	g := deprun.New(deprun.WithRunToCompletion())
+
+	var attempts int
+	g.AddTask(func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	}, deprun.Retry(deprun.RetryPolicy{MaxAttempts: 5, Backoff: time.Millisecond}))
+
+	if err := g.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want, have := 3, attempts; want != have {
+		t.Errorf("want %d attempts, have %d", want, have)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	g := deprun.New(deprun.WithRunToCompletion())
+
+	var attempts int
+	myError := errors.New("permanent")
+	g.AddTask(func(context.Context) error {
+		attempts++
+		return myError
+	}, deprun.Retry(deprun.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+
+	if err := g.Run(); !errors.Is(err, myError) {
+		t.Errorf("want %v, have %v", myError, err)
+	}
+
+	if want, have := 3, attempts; want != have {
+		t.Errorf("want %d attempts, have %d", want, have)
+	}
+}
+
+func TestRetryStopsOnTeardown(t *testing.T) {
+	var g deprun.Group
+
+	var attempts int
+	myError := errors.New("transient")
+	g.AddTask(func(context.Context) error {
+		attempts++
+		return myError
+	}, deprun.Retry(deprun.RetryPolicy{MaxAttempts: 100, Backoff: time.Hour}), deprun.NonCritical())
+
+	stop := errors.New("stop")
+	g.Add(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return stop
+	}, func(error) {})
+
+	done := make(chan error, 1)
+	go func() { done <- g.Run() }()
+
+	select {
+	case err := <-done:
+		if want, have := stop, err; want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("retry backoff blocked teardown")
+	}
+
+	if want, have := 1, attempts; want != have {
+		t.Errorf("want %d attempts, have %d", want, have)
+	}
+}
@@ -0,0 +1,63 @@
+package deprun_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestParseCron(t *testing.T) {
+	base := time.Date(2024, time.January, 31, 10, 17, 42, 0, time.UTC) // Wednesday
+
+	for _, tc := range []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 31, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 31, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17 * * *", time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2024, time.February, 1, 2, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 5", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"5,10 12 * * *", time.Date(2024, time.January, 31, 12, 5, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		s, err := deprun.ParseCron(tc.spec)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.spec, err)
+		}
+
+		if want, have := tc.want, s.Next(base); !want.Equal(have) {
+			t.Errorf("%q: want %v, have %v", tc.spec, want, have)
+		}
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := deprun.ParseCron(spec); err == nil {
+			t.Errorf("%q: want error, have nil", spec)
+		}
+	}
+}
+
+func TestParseCronNeverMatches(t *testing.T) {
+	s, err := deprun.ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have := s.Next(time.Now()); !have.IsZero() {
+		t.Errorf("want zero time, have %v", have)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	now := time.Now()
+	if want, have := now.Add(time.Minute), deprun.Every(time.Minute).Next(now); !want.Equal(have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
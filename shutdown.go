@@ -0,0 +1,128 @@
+package deprun
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithShutdownTimeout sets the shutdown budget of the group: the context
+// passed to the interrupt functions of actors added with AddGraceful expires
+// d after teardown begins. Without a timeout that context never expires.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(g *Group) {
+		g.shutdownTimeout = d
+	}
+}
+
+// AddGraceful adds an actor like Add, except that its interrupt function
+// also receives a context carrying the remaining shutdown budget, see
+// WithShutdownTimeout. This suits graceful-stop implementations that accept
+// a deadline, such as http.Server.Shutdown:
+//
+//	g.AddGraceful(func() error {
+//		return srv.ListenAndServe()
+//	}, func(ctx context.Context, _ error) {
+//		srv.Shutdown(ctx)
+//	})
+//
+// Unlike other interrupt functions, which are called one after another,
+// shutdown is called on its own goroutine and may block until the actor has
+// drained. Run waits for it to return.
+func (g *Group) AddGraceful(execute func() error, shutdown func(ctx context.Context, err error), opts ...ActorOption) {
+	g.add(actor{
+		execute:   addExecute(execute),
+		interrupt: func(error) {},
+		shutdown:  shutdown,
+	}, opts)
+}
+
+// shutdownContext returns the context carrying the shutdown budget.
+func (g *Group) shutdownContext() (context.Context, context.CancelFunc) {
+	if g.shutdownTimeout > 0 {
+		return withTimeout(context.Background(), g.clockOrSystem(), g.shutdownTimeout)
+	}
+
+	return context.WithCancel(context.Background())
+}
+
+// ForceInterrupt registers a second, forceful interrupt function for an
+// actor. It is called with the teardown error if execute has not returned
+// within grace after the regular interrupt function was called. A typical
+// pairing is http.Server's Shutdown as the regular interrupt and Close as
+// the forceful one.
+func ForceInterrupt(grace time.Duration, force func(error)) ActorOption {
+	return actorOption(func(a *actor) {
+		a.force = force
+		a.forceGrace = grace
+	})
+}
+
+// forceInterrupt calls the forceful interrupt function unless the actor
+// exits within the grace period.
+func (a *actor) forceInterrupt(err error) {
+	timer := a.clock.NewTimer(a.forceGrace)
+	defer timer.Stop()
+
+	select {
+	case <-a.exited:
+	case <-timer.C():
+		a.force(err)
+	}
+}
+
+// WithSlowInterrupt calls onSlow with the name of every actor that has not
+// returned within d after its interrupt function was called, to find the
+// components that ignore shutdown. It is called at most once per actor and
+// run, from a goroutine of the group; it does not affect the teardown.
+func WithSlowInterrupt(d time.Duration, onSlow func(actor string)) Option {
+	return func(g *Group) {
+		g.slowInterrupt = d
+		g.onSlowInterrupt = onSlow
+	}
+}
+
+// watchInterrupt calls the slow interrupt callback unless the actor exits
+// within the slow interrupt duration.
+func (g *Group) watchInterrupt(a *actor) {
+	timer := a.clock.NewTimer(g.slowInterrupt)
+	defer timer.Stop()
+
+	select {
+	case <-a.exited:
+	case <-timer.C():
+		g.onSlowInterrupt(a.String())
+	}
+}
+
+// interruptActor interrupts a alone, outside of the teardown, e.g. on
+// timeout. Interrupt functions are made safe for that by interruptOnce.
+func (g *Group) interruptActor(a *actor, err error) {
+	if a.shutdown == nil {
+		a.interrupt(err)
+
+		return
+	}
+
+	ctx, cancel := g.shutdownContext()
+	defer cancel()
+
+	a.shutdown(ctx, err)
+}
+
+// interruptOnce makes the interrupt functions of a safe to call both by
+// interruptActor and on teardown, for the current run.
+func (a *actor) interruptOnce() {
+	interrupt := a.interrupt
+	var once sync.Once
+	a.interrupt = func(err error) {
+		once.Do(func() { interrupt(err) })
+	}
+
+	if shutdown := a.shutdown; shutdown != nil {
+		var once sync.Once
+		a.shutdown = func(ctx context.Context, err error) {
+			once.Do(func() { shutdown(ctx, err) })
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestForceInterrupt(t *testing.T) {
+	var g deprun.Group
+	var forced atomic.Bool
+	kill := make(chan struct{})
+	g.AddWith(func() error {
+		<-kill // ignores the graceful interrupt
+		return nil
+	}, func(error) {}, deprun.ForceInterrupt(10*time.Millisecond, func(error) {
+		forced.Store(true)
+		close(kill)
+	}))
+	myError := errors.New("teardown")
+	g.Add(func() error { return myError }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if !forced.Load() {
+		t.Error("forceful interrupt was not called")
+	}
+}
+
+func TestForceInterruptNotNeeded(t *testing.T) {
+	var g deprun.Group
+	stop := make(chan struct{})
+	g.AddWith(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.ForceInterrupt(10*time.Millisecond, func(error) {
+		t.Error("forceful interrupt called although the actor stopped gracefully")
+	}))
+	myError := errors.New("teardown")
+	g.Add(func() error { return myError }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestAddGraceful(t *testing.T) {
+	g := deprun.New(deprun.WithShutdownTimeout(time.Minute))
+	stop := make(chan struct{})
+	var budget atomic.Int64
+	g.AddGraceful(func() error {
+		<-stop
+		return nil
+	}, func(ctx context.Context, err error) {
+		if deadline, ok := ctx.Deadline(); ok {
+			budget.Store(int64(time.Until(deadline)))
+		}
+		time.Sleep(10 * time.Millisecond) // drain
+		close(stop)
+	})
+	myError := errors.New("teardown")
+	g.Add(func() error { return myError }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if b := time.Duration(budget.Load()); b <= 0 || b > time.Minute {
+		t.Errorf("shutdown budget: want (0, 1m], have %v", b)
+	}
+}
+
+func TestAddGracefulExpired(t *testing.T) {
+	g := deprun.New(deprun.WithShutdownTimeout(10 * time.Millisecond))
+	stop := make(chan struct{})
+	g.AddGraceful(func() error {
+		<-stop
+		return nil
+	}, func(ctx context.Context, err error) {
+		<-ctx.Done() // never drains on its own
+		close(stop)
+	})
+	myError := errors.New("teardown")
+	g.Add(func() error { return myError }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestWithSlowInterrupt(t *testing.T) {
+	var slow []string
+	g := deprun.New(deprun.WithSlowInterrupt(10*time.Millisecond, func(actor string) {
+		slow = append(slow, actor)
+	}))
+
+	stop := make(chan struct{})
+	g.AddWith(func() error {
+		<-stop
+		time.Sleep(50 * time.Millisecond) // slow to shut down
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("stubborn"))
+
+	quit := make(chan struct{})
+	g.AddWith(func() error {
+		<-quit
+		return nil
+	}, func(error) { close(quit) }, deprun.Name("prompt"))
+
+	myError := errors.New("teardown")
+	g.AddWith(func() error { return myError }, func(error) {}, deprun.Name("trigger"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := "[stubborn]", fmt.Sprint(slow); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestInterruptRerun(t *testing.T) {
+	var (
+		g          deprun.Group
+		interrupts int
+		stop       chan struct{}
+	)
+
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(error) {
+		interrupts++
+		close(stop)
+	})
+
+	myError := errors.New("done")
+	g.Add(func() error { return myError }, func(error) {})
+
+	for range 2 {
+		stop = make(chan struct{})
+		if want, have := myError, g.Clone().Run(); want != have {
+			t.Errorf("want %v, have %v", want, have)
+		}
+	}
+
+	if want, have := 2, interrupts; want != have {
+		t.Errorf("want %d interrupts, have %d", want, have)
+	}
+}
@@ -0,0 +1,44 @@
+package deprun
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// pkgPrefix prefixes the names of the functions of this package.
+var pkgPrefix = reflect.TypeFor[Group]().PkgPath() + "."
+
+// callSite returns where the caller of the package registered an actor, as
+// the directory and name of the file and the line, e.g.
+// "ingest/setup.go:87", or "" if it is unknown.
+func callSite() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			dir, file := filepath.Split(frame.File)
+
+			return fmt.Sprintf("%s:%d", filepath.ToSlash(filepath.Join(filepath.Base(dir), file)), frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// qualified returns the name of a and, if it is unnamed, where it was
+// registered, e.g. "#3 (ingest/setup.go:87)", to identify it in errors and
+// diagnostics.
+func (a *actor) qualified() string {
+	if a.name != "" || a.site == "" {
+		return a.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", a, a.site)
+}
@@ -0,0 +1,30 @@
+package deprun_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestCallSite(t *testing.T) {
+	var g deprun.Group
+
+	db := g.AddDep(func(deprun.ReadySignal) error { return nil }, func(error) {})
+	g.AddWith(func() error { return nil }, func(error) {}, db, deprun.Name("api"))
+
+	var never *deprun.DependencyNeverReadyError
+	if err := g.Run(); !errors.As(err, &never) {
+		t.Fatalf("want %T, have %v", never, err)
+	}
+
+	if want, have := "#0 (", never.Provider; !strings.HasPrefix(have, want) || !strings.HasSuffix(have, "site_test.go:14)") {
+		t.Errorf("want %q followed by the call site, have %q", want, have)
+	}
+
+	s := g.Snapshot()
+	if want, have := "site_test.go:15", s.Actors[1].Site; !strings.HasSuffix(have, want) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
@@ -0,0 +1,96 @@
+package deprun
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// WithSlog logs the lifecycle of the group to logger: group start, actor
+// start, readiness, exit and interrupt, the cause of the teardown and the
+// result of Run. Records carry the actor name, the time since the actor started
+// and the error, if any, as attributes. Actor failures are logged at level
+// Error, interrupts at level Debug and everything else at level Info.
+func WithSlog(logger *slog.Logger) Option {
+	return WithObserver(&slogObserver{
+		logger:  logger,
+		clock:   systemClock{},
+		started: make(map[int]time.Time),
+	})
+}
+
+// slogObserver is the Observer installed by WithSlog.
+type slogObserver struct {
+	logger *slog.Logger
+
+	mu          sync.Mutex
+	clock       Clock
+	started     map[int]time.Time // by actor index
+	tearingDown bool
+}
+
+func (o *slogObserver) OnGroupStart(actors []ActorInfo, clock Clock) {
+	o.mu.Lock()
+	o.clock = clock
+	o.mu.Unlock()
+
+	o.logger.Info("group starting", slog.Int("actors", len(actors)))
+}
+
+func (o *slogObserver) OnActorStart(actor ActorInfo) {
+	o.mu.Lock()
+	o.started[actor.Index] = o.clock.Now()
+	o.mu.Unlock()
+
+	o.logger.Info("actor started", slog.String("actor", actor.Name))
+}
+
+func (o *slogObserver) OnActorReady(actor ActorInfo) {
+	o.logger.Info("actor ready", slog.String("actor", actor.Name), o.uptime(actor))
+}
+
+func (o *slogObserver) OnActorExit(actor ActorInfo, err error) {
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+
+	o.logger.Log(context.Background(), level, "actor exited",
+		slog.String("actor", actor.Name), o.uptime(actor), slog.Any("error", err))
+}
+
+func (o *slogObserver) OnInterrupt(actor ActorInfo, err error) {
+	o.mu.Lock()
+	first := !o.tearingDown
+	o.tearingDown = true
+	o.mu.Unlock()
+
+	if first {
+		o.logger.Info("group tearing down", slog.Any("cause", err))
+	}
+
+	o.logger.Debug("actor interrupted", slog.String("actor", actor.Name), slog.Any("error", err))
+}
+
+func (o *slogObserver) OnGroupDone(err error) {
+	o.mu.Lock()
+	o.tearingDown = false
+	clear(o.started)
+	o.mu.Unlock()
+
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+
+	o.logger.Log(context.Background(), level, "group stopped", slog.Any("error", err))
+}
+
+// uptime returns the time since actor started as a "duration" attribute.
+func (o *slogObserver) uptime(actor ActorInfo) slog.Attr {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return slog.Duration("duration", since(o.clock, o.started[actor.Index]))
+}
@@ -0,0 +1,39 @@
+package deprun_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestWithSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g := deprun.New(deprun.WithSlog(logger))
+
+	myError := errors.New("boom")
+	g.AddWith(func() error { return myError }, func(error) {}, deprun.Name("api"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`level=INFO msg="actor started" actor=api`,
+		`level=INFO msg="actor ready" actor=api duration=`,
+		`level=ERROR msg="actor exited" actor=api duration=`,
+		`error=boom`,
+		`level=INFO msg="group tearing down" cause=boom`,
+		`level=DEBUG msg="actor interrupted" actor=api error=boom`,
+		`level=ERROR msg="group stopped" error=boom`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
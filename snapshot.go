@@ -0,0 +1,90 @@
+package deprun
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Snapshot describes the dependency graph and the runtime state of a group
+// at one point in time, for dashboards and debugging tools. It marshals to
+// JSON, see Group.MarshalJSON.
+type Snapshot struct {
+	Status string          `json:"status"` // "idle", "running", "stopping" or "stopped"
+	Actors []ActorSnapshot `json:"actors"` // in registration order
+}
+
+// ActorSnapshot describes an actor in a Snapshot. Times are zero for steps
+// the actor has not reached in the current or last run.
+type ActorSnapshot struct {
+	Name   string     `json:"name"`
+	Tags   []string   `json:"tags,omitempty"`
+	State  ActorState `json:"state"`
+	Paused bool       `json:"paused,omitempty"` // see Group.Pause
+	Site   string     `json:"site,omitempty"`   // where it was registered, e.g. "ingest/setup.go:87"
+
+	Meta      map[string]string `json:"meta,omitempty"`       // see Meta
+	DependsOn []string          `json:"depends_on,omitempty"` // the actors it depends on
+
+	// External reports whether the actor also depends on something no actor
+	// of the group provides.
+	External bool `json:"external,omitempty"`
+
+	Started time.Time `json:"started,omitzero"`
+	Ready   time.Time `json:"ready,omitzero"`
+	Exited  time.Time `json:"exited,omitzero"`
+	Err     error     `json:"-"`
+	Error   string    `json:"error,omitempty"` // the message of Err
+}
+
+// Snapshot returns the dependency graph and the current state of the
+// group. It is safe to call concurrently with Run.
+func (g *Group) Snapshot() Snapshot {
+	actors := g.graph()
+	providers := providerIndices(actors)
+
+	s := Snapshot{
+		Status: groupStatuses[g.status.Load()],
+		Actors: make([]ActorSnapshot, len(actors)),
+	}
+
+	for i := range actors {
+		a := &actors[i]
+		times, err := a.history()
+		as := ActorSnapshot{
+			Name:    a.String(),
+			Tags:    a.tags,
+			State:   a.currentState(),
+			Paused:  a.paused(),
+			Site:    a.site,
+			Meta:    a.meta,
+			Started: times[Running],
+			Ready:   times[Ready],
+			Exited:  times[Stopped],
+			Err:     err,
+		}
+
+		if err != nil {
+			as.Error = err.Error()
+		}
+
+		deps, external := a.edges(providers)
+		for _, dep := range deps {
+			as.DependsOn = append(as.DependsOn, actors[dep].String())
+		}
+
+		as.External = external
+		s.Actors[i] = as
+	}
+
+	return s
+}
+
+// MarshalJSON encodes the Snapshot of the group.
+func (g *Group) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Snapshot())
+}
+
+// MarshalText encodes the state as its String.
+func (s ActorState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
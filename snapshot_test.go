@@ -0,0 +1,115 @@
+package deprun_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestSnapshot(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	myError := errors.New("foobar")
+	g.AddWith(func() error { return myError }, nil, db, deprun.Name("api"))
+
+	if want, have := "idle", g.Snapshot().Status; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	g.Run()
+
+	s := g.Snapshot()
+	if want, have := "stopped", s.Status; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if want, have := 2, len(s.Actors); want != have {
+		t.Fatalf("want %d actors, have %d", want, have)
+	}
+
+	dbs, api := s.Actors[0], s.Actors[1]
+	if dbs.Started.IsZero() || dbs.Ready.IsZero() || dbs.Exited.IsZero() {
+		t.Errorf("db: missing times: %+v", dbs)
+	}
+
+	if dbs.Ready.Before(dbs.Started) || api.Started.Before(dbs.Ready) {
+		t.Errorf("times out of order: db %+v, api %+v", dbs, api)
+	}
+
+	if want, have := myError, api.Err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := "db", api.DependsOn; len(have) != 1 || have[0] != want {
+		t.Errorf("want [%s], have %v", want, have)
+	}
+
+	b, err := json.Marshal(&g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Status string
+		Actors []struct {
+			Name      string
+			State     string
+			DependsOn []string `json:"depends_on"`
+			Ready     string
+			Error     string
+		}
+	}
+
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := "stopped", decoded.Actors[1].State; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if want, have := "foobar", decoded.Actors[1].Error; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if decoded.Actors[0].Ready == "" {
+		t.Errorf("missing ready time in %s", b)
+	}
+
+	if _, ok := decodedKeys(t, b)["exited"]; !ok {
+		t.Errorf("missing exited time in %s", b)
+	}
+
+	var idle deprun.Group
+	idle.Add(func() error { return nil }, nil)
+
+	b, err = json.Marshal(&idle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := decodedKeys(t, b)["started"]; ok {
+		t.Error("idle actor has a start time")
+	}
+}
+
+// decodedKeys returns the keys of the first actor of a marshaled group.
+func decodedKeys(t *testing.T, b []byte) map[string]any {
+	t.Helper()
+
+	var decoded struct{ Actors []map[string]any }
+	if err := json.Unmarshal(b, &decoded); err != nil || len(decoded.Actors) == 0 {
+		t.Fatalf("decoding %s: %v", b, err)
+	}
+
+	return decoded.Actors[0]
+}
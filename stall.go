@@ -0,0 +1,108 @@
+package deprun
+
+import (
+	"slices"
+	"time"
+)
+
+// StalledActor describes an actor that has not become ready, see
+// WithStallWatchdog.
+type StalledActor struct {
+	Name       string
+	State      ActorState // WaitingDeps or Running
+	WaitingFor []string   // what a WaitingDeps actor is still waiting for, see Group.Dump
+}
+
+// WithStallWatchdog calls onStall when no actor of the running group has
+// changed its state, e.g. started or become ready, for d while some actors
+// are not ready yet. onStall receives those actors and, for actors waiting
+// for their dependencies, the dependencies that are not resolved. It is
+// called once per stall, from a goroutine of the group, and again only
+// after the group made progress and stalled anew.
+//
+// Unlike WithStartupTimeout, the watchdog does not tear the group down; it
+// makes silent startup deadlocks visible, e.g. by logging them.
+func WithStallWatchdog(d time.Duration, onStall func(stalled []StalledActor)) Option {
+	return func(g *Group) {
+		g.stallTimeout = d
+		g.onStall = onStall
+	}
+}
+
+// stallWatchdog returns a hidden actor watching actors for stalls.
+func stallWatchdog(clock Clock, d time.Duration, onStall func([]StalledActor), actors []actor) actor {
+	actors = slices.Clip(actors)
+	stop := make(chan struct{})
+
+	return actor{
+		execute: func(ReadySignal) error {
+			providers := providerNames(actors)
+
+			interval := max(d/4, time.Millisecond)
+			timer := clock.NewTimer(interval)
+			defer timer.Stop()
+
+			var (
+				last     = -1
+				since    time.Time
+				reported bool
+			)
+
+			for {
+				select {
+				case <-timer.C():
+					timer.Reset(interval)
+				case <-stop:
+					return nil
+				}
+
+				// States only move forward, so their sum grows with every
+				// change.
+				var progress int
+				for i := range actors {
+					progress += int(actors[i].currentState())
+				}
+
+				if progress != last {
+					last, since, reported = progress, clock.Now(), false
+
+					continue
+				}
+
+				if reported || clock.Now().Sub(since) < d {
+					continue
+				}
+
+				if stalled := stalledActors(actors, providers); len(stalled) > 0 {
+					onStall(stalled)
+					reported = true
+				}
+			}
+		},
+		interrupt: func(error) { close(stop) },
+		provides:  newDependency(),
+		hidden:    true,
+	}
+}
+
+// stalledActors returns the actors that are waiting for their dependencies
+// or running without being ready.
+func stalledActors(actors []actor, providers map[*Dependency]string) []StalledActor {
+	var stalled []StalledActor
+
+	for i := range actors {
+		a := &actors[i]
+		if a.hidden {
+			continue
+		}
+
+		switch state := a.currentState(); state {
+		case WaitingDeps:
+			stalled = append(stalled, StalledActor{Name: a.String(), State: state, WaitingFor: a.pendingDeps(providers)})
+		case Running:
+			stalled = append(stalled, StalledActor{Name: a.String(), State: state})
+		}
+	}
+
+	return stalled
+}
@@ -0,0 +1,77 @@
+package deprun_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestStallWatchdog(t *testing.T) {
+	stalls := make(chan []deprun.StalledActor, 10)
+	g := deprun.New(deprun.WithStallWatchdog(20*time.Millisecond, func(stalled []deprun.StalledActor) {
+		stalls <- stalled
+	}))
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(deprun.ReadySignal) error {
+		<-stop // never ready
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+	g.AddWith(func() error { return nil }, func(error) {}, db, deprun.Name("api"))
+
+	myError := errors.New("done")
+	g.AddWith(func() error {
+		time.Sleep(100 * time.Millisecond)
+		return myError
+	}, func(error) {}, deprun.Name("timer"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if want, have := 1, len(stalls); want != have {
+		t.Fatalf("want %d stall, have %d", want, have)
+	}
+
+	want := []deprun.StalledActor{
+		{Name: "db", State: deprun.Running},
+		{Name: "api", State: deprun.WaitingDeps, WaitingFor: []string{"db"}},
+	}
+	have := <-stalls
+	if !slices.EqualFunc(want, have, func(a, b deprun.StalledActor) bool {
+		return a.Name == b.Name && a.State == b.State && slices.Equal(a.WaitingFor, b.WaitingFor)
+	}) {
+		t.Errorf("want %+v, have %+v", want, have)
+	}
+}
+
+func TestStallWatchdogReady(t *testing.T) {
+	var stalled bool
+	g := deprun.New(deprun.WithStallWatchdog(10*time.Millisecond, func([]deprun.StalledActor) {
+		stalled = true
+	}))
+
+	stop := make(chan struct{})
+	g.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		time.Sleep(50 * time.Millisecond)
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	if stalled {
+		t.Error("unexpected stall of a ready group")
+	}
+}
@@ -0,0 +1,71 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrStartupTimeout is matched, via errors.Is, by the error Run returns when
+// the group did not become ready within the startup timeout.
+var ErrStartupTimeout = errors.New("startup timeout")
+
+// StartupTimeoutError is returned by Run when actors added with AddDep did
+// not all signal ready within the timeout set by WithStartupTimeout.
+type StartupTimeoutError struct {
+	Timeout time.Duration
+	Pending []string // the actors that were not ready, unnamed ones with their call site
+}
+
+// Error implements the error interface.
+func (e *StartupTimeoutError) Error() string {
+	return fmt.Sprintf("deprun: not ready after %v, pending: %s", e.Timeout, strings.Join(e.Pending, ", "))
+}
+
+// Is makes errors.Is(err, ErrStartupTimeout) report true.
+func (e *StartupTimeoutError) Is(target error) bool {
+	return target == ErrStartupTimeout
+}
+
+// WithStartupTimeout fails Run with a *StartupTimeoutError if the group is
+// not ready, see Group.Ready, within d after Run is called. The group is
+// torn down with that error, which names the actors still pending.
+func WithStartupTimeout(d time.Duration) Option {
+	return func(g *Group) {
+		g.startupTimeout = d
+	}
+}
+
+// startupDeadline returns a hidden actor that fails once timeout elapses
+// before ready resolves.
+func startupDeadline(clock Clock, timeout time.Duration, ready *Dependency, actors []actor) actor {
+	stop := make(chan struct{})
+
+	return actor{
+		execute: func(ReadySignal) error {
+			timer := clock.NewTimer(timeout)
+			defer timer.Stop()
+
+			select {
+			case <-ready.Done():
+			case <-timer.C():
+				var pending []string
+				for _, a := range actors {
+					if a.awaited() && !a.provides.isReady() {
+						pending = append(pending, a.qualified())
+					}
+				}
+
+				return &StartupTimeoutError{Timeout: timeout, Pending: pending}
+			}
+
+			<-stop
+
+			return nil
+		},
+		interrupt: func(error) { close(stop) },
+		provides:  newDependency(),
+		hidden:    true,
+	}
+}
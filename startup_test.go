@@ -0,0 +1,61 @@
+package deprun_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestStartupTimeout(t *testing.T) {
+	g := deprun.New(deprun.WithStartupTimeout(20 * time.Millisecond))
+	stop := make(chan struct{})
+	g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("config"))
+	g.AddDepWith(func(deprun.ReadySignal) error {
+		<-stop // never ready
+		return nil
+	}, func(error) {}, deprun.Name("db"))
+	g.AddDep(func(deprun.ReadySignal) error {
+		<-stop // never ready
+		return nil
+	}, func(error) {})
+
+	err := g.Run()
+	if !errors.Is(err, deprun.ErrStartupTimeout) {
+		t.Fatalf("want %v, have %v", deprun.ErrStartupTimeout, err)
+	}
+
+	var timeoutErr *deprun.StartupTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("want *StartupTimeoutError, have %T", err)
+	}
+	// The unnamed actor is identified by where it was registered.
+	if want, have := "deprun: not ready after 20ms, pending: db, #2 (", err.Error(); !strings.HasPrefix(have, want) || !strings.HasSuffix(have, "startup_test.go:24)") {
+		t.Errorf("want %q followed by the call site, have %q", want, have)
+	}
+}
+
+func TestStartupTimeoutReady(t *testing.T) {
+	g := deprun.New(deprun.WithStartupTimeout(20 * time.Millisecond))
+	stop := make(chan struct{})
+	g.AddDep(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+	myError := errors.New("done")
+	g.Add(func() error {
+		time.Sleep(50 * time.Millisecond)
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
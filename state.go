@@ -0,0 +1,147 @@
+package deprun
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ActorState is the state of an actor, see Group.States. An actor goes
+// through the states in order, possibly skipping some of them.
+type ActorState int32
+
+// States of an actor.
+const (
+	Pending     ActorState = iota // Run has not started the actor yet
+	WaitingDeps                   // waiting for its dependencies to be ready
+	Running                       // started, not ready yet
+	Ready                         // started and ready
+	Stopping                      // interrupted, not returned yet
+	Stopped                       // returned, or never started
+	Disabled                      // skipped for this run, see Enabled
+)
+
+var actorStates = [...]string{
+	Pending:     "pending",
+	WaitingDeps: "waiting for dependencies",
+	Running:     "running",
+	Ready:       "ready",
+	Stopping:    "stopping",
+	Stopped:     "stopped",
+	Disabled:    "disabled",
+}
+
+func (s ActorState) String() string {
+	if s < 0 || int(s) >= len(actorStates) {
+		return "unknown"
+	}
+
+	return actorStates[s]
+}
+
+// ActorStatus is the state of a named actor.
+type ActorStatus struct {
+	Name  string
+	State ActorState
+}
+
+// States returns the current state of every actor, in registration order.
+// It is safe to call concurrently with Run, e.g. from an admin endpoint.
+func (g *Group) States() []ActorStatus {
+	actors := g.registered()
+	states := make([]ActorStatus, len(actors))
+	for i := range actors {
+		a := &actors[i]
+		states[i] = ActorStatus{Name: a.String(), State: a.currentState()}
+	}
+
+	return states
+}
+
+// currentState returns the state of a. Hidden actors are always Pending.
+func (a *actor) currentState() ActorState {
+	if a.state == nil {
+		return Pending
+	}
+
+	return ActorState(a.state.Load())
+}
+
+// setState moves a to state s, unless it is already further along, and
+// records when it did. Hidden actors have no state.
+func (a *actor) setState(s ActorState) {
+	if a.state == nil {
+		return
+	}
+
+	for {
+		old := a.state.Load()
+		if old >= int32(s) {
+			return
+		}
+
+		if a.state.CompareAndSwap(old, int32(s)) {
+			a.state.mu.Lock()
+			a.state.times[s] = a.clock.Now()
+			a.state.mu.Unlock()
+
+			return
+		}
+	}
+}
+
+// resetState moves a to s, even backwards, when a new run starts.
+func (a *actor) resetState(s ActorState) {
+	if a.state == nil {
+		return
+	}
+
+	a.state.mu.Lock()
+	a.state.times = [len(actorStates)]time.Time{}
+	a.state.times[s] = a.clock.Now()
+	a.state.err = nil
+	a.state.mu.Unlock()
+
+	a.state.targeted.Store(false)
+	a.state.paused.Store(false)
+
+	a.state.Store(int32(s))
+}
+
+// setErr records the error a exited with.
+func (a *actor) setErr(err error) {
+	if a.state == nil {
+		return
+	}
+
+	a.state.mu.Lock()
+	a.state.err = err
+	a.state.mu.Unlock()
+}
+
+// actorState is the state of an actor during a run, shared by all copies of
+// the actor.
+type actorState struct {
+	atomic.Int32 // an ActorState
+
+	mu    sync.Mutex
+	times [len(actorStates)]time.Time // when the actor reached each state
+	err   error                       // the error the actor exited with
+
+	targeted atomic.Bool // stopped by InterruptTag
+
+	pauseMu sync.Mutex  // serializes Pause and Resume
+	paused  atomic.Bool // see Group.Pause
+}
+
+// history returns when a reached each state and the error it exited with.
+func (a *actor) history() (times [len(actorStates)]time.Time, err error) {
+	if a.state == nil {
+		return times, nil
+	}
+
+	a.state.mu.Lock()
+	defer a.state.mu.Unlock()
+
+	return a.state.times, a.state.err
+}
@@ -0,0 +1,74 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestStates(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	cacheStarted := make(chan struct{})
+	cache := g.AddDepWith(func(deprun.ReadySignal) error {
+		close(cacheStarted)
+		<-stop
+		return nil
+	}, func(error) {}, deprun.Name("cache"))
+
+	g.AddWith(func() error { return nil }, func(error) {}, cache, deprun.Name("worker"))
+
+	var during, interrupting []deprun.ActorStatus
+	myError := errors.New("boom")
+	g.AddWith(func() error {
+		<-cacheStarted
+		during = g.States()
+		return myError
+	}, func(error) {
+		interrupting = g.States()
+	}, db, deprun.Name("api"))
+
+	for _, s := range g.States() {
+		if want, have := deprun.Pending, s.State; want != have {
+			t.Errorf("before run: %s: want %v, have %v", s.Name, want, have)
+		}
+	}
+
+	if want, have := myError, g.Run(); want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	for i, want := range []deprun.ActorStatus{
+		{"db", deprun.Ready},
+		{"cache", deprun.Running},
+		{"worker", deprun.WaitingDeps},
+		{"api", deprun.Ready},
+	} {
+		if have := during[i]; want != have {
+			t.Errorf("during run: want %v, have %v", want, have)
+		}
+	}
+
+	// api is interrupted last, after db was interrupted.
+	if want, have := deprun.Stopped, interrupting[3].State; want != have {
+		t.Errorf("api: want %v, have %v", want, have)
+	}
+
+	if have := interrupting[0].State; have != deprun.Stopping && have != deprun.Stopped {
+		t.Errorf("db: want stopping or stopped, have %v", have)
+	}
+
+	for _, s := range g.States() {
+		if want, have := deprun.Stopped, s.State; want != have {
+			t.Errorf("after run: %s: want %v, have %v", s.Name, want, have)
+		}
+	}
+}
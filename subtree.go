@@ -0,0 +1,157 @@
+package deprun
+
+import "time"
+
+// RestartSubtree makes a provider that fails restart instead of tearing
+// the group down, the way services recover from a dependency blip: its
+// dependents, direct or transitive, are interrupted with
+// ErrDependencyUnready, the provider executes again after the backoff of
+// policy, and the dependents execute again once their dependencies are
+// ready again. Actors that do not depend on it keep running. Once the
+// provider failed policy.MaxAttempts times in a run, its last error
+// propagates as usual.
+//
+// Like with Clone, execute must create the state that interrupt acts on
+// each time it runs, for the provider and its dependents.
+func RestartSubtree(policy RetryPolicy) ActorOption {
+	if policy.Backoff <= 0 {
+		policy.Backoff = probeMinBackoff
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = probeMaxBackoff
+	}
+
+	return actorOption(func(a *actor) {
+		a.restart = &policy
+	})
+}
+
+// restartSubtrees sets, for the actors depending on a provider with
+// RestartSubtree, the dependencies whose restart restarts them, and makes
+// the dependencies of the subtrees re-armable so that they can go down.
+func restartSubtrees(actors []actor) {
+	providers := providerIndices(actors)
+	dependents := make([][]int, len(actors))
+	for i := range actors {
+		if actors[i].hidden {
+			continue
+		}
+
+		deps, _ := actors[i].edges(providers)
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+
+	inSubtree := make([]bool, len(actors))
+
+	var mark func(i int)
+	mark = func(i int) {
+		if inSubtree[i] {
+			return
+		}
+
+		inSubtree[i] = true
+		if actors[i].provides.rearm == nil {
+			actors[i].provides.rearm = newRearm(UnreadyBlock)
+		}
+
+		for _, d := range dependents[i] {
+			actors[d].restartDeps = append(actors[d].restartDeps, actors[i].provides)
+			mark(d)
+		}
+	}
+
+	for i := range actors {
+		if actors[i].restart != nil && !actors[i].hidden {
+			mark(i)
+		}
+	}
+}
+
+// watchDeps stops the execution e once one of the restart dependencies of
+// a goes down; a runs again once they are all available.
+func (a *actor) watchDeps(e *execution) {
+	down := make(chan struct{}, 1)
+	for _, d := range a.restartDeps {
+		go func() {
+			for {
+				changed := d.Changed()
+				if d.rearm.isDown() {
+					select {
+					case down <- struct{}{}:
+					default:
+					}
+
+					return
+				}
+
+				select {
+				case <-changed:
+				case <-e.done:
+					return
+				}
+			}
+		}()
+	}
+
+	select {
+	case <-down:
+	case <-e.done:
+		return
+	}
+
+	if e.claim(ErrDependencyUnready, a.resumeDeps) {
+		a.provides.rearm.set(true)
+		e.interrupt(ErrDependencyUnready)
+	}
+}
+
+// resumeDeps blocks until the restart dependencies of a are available and
+// reports true, or reports false once the group is torn down.
+func (a *actor) resumeDeps(<-chan struct{}) bool {
+	for _, d := range a.restartDeps {
+		if !d.rearm.waitAvailable() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDown reports whether the provider of the dependency called its
+// UnreadySignal, or is restarting, and has not stopped.
+func (r *rearm) isDown() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.down && !r.stopped
+}
+
+// backoff returns the delay before the restart following the given number
+// of failures.
+func (p *RetryPolicy) backoff(failures int) time.Duration {
+	d := p.Backoff
+	for range failures - 1 {
+		d = min(2*d, p.MaxBackoff)
+	}
+
+	return d
+}
+
+// restartAfter takes the dependency down for a restart after d, and
+// reports true, or reports false once stopped is closed.
+func (r *rearm) restartAfter(clock Clock, d time.Duration, stopped <-chan struct{}) bool {
+	r.set(true)
+
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return true
+	case <-stopped:
+		return false
+	}
+}
@@ -0,0 +1,167 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+// restartable returns an actor that counts its executions and runs until
+// interrupted. The first execution that sees blip closed fails.
+func restartable(blip <-chan struct{}) (func(deprun.ReadySignal) error, func(error), *atomic.Int32) {
+	var (
+		executions atomic.Int32
+		failed     atomic.Bool
+		stop       atomic.Pointer[chan struct{}]
+	)
+
+	return func(ready deprun.ReadySignal) error {
+			executions.Add(1)
+			c := make(chan struct{})
+			stop.Store(&c)
+			ready()
+
+			select {
+			case <-c:
+				return nil
+			case <-blip:
+				if !failed.Swap(true) {
+					return errors.New("blip")
+				}
+			}
+
+			<-c
+
+			return nil
+		}, func(error) {
+			if c := stop.Load(); c != nil {
+				close(*c)
+			}
+		}, &executions
+}
+
+func TestRestartSubtree(t *testing.T) {
+	var (
+		g    deprun.Group
+		blip = make(chan struct{})
+	)
+
+	execute, interrupt, db := restartable(blip)
+	dbDep := g.AddDepWith(execute, interrupt, deprun.Name("db"), deprun.RestartSubtree(deprun.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	}))
+
+	execute, interrupt, repo := restartable(nil)
+	repoDep := g.AddDepWith(execute, interrupt, dbDep, deprun.Name("repo"))
+
+	execute, interrupt, api := restartable(nil)
+	g.AddDepWith(execute, interrupt, repoDep, deprun.Name("api"))
+
+	execute, interrupt, metrics := restartable(nil)
+	g.AddDepWith(execute, interrupt, deprun.Name("metrics"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		await(t, func() bool { return g.States()[2].State == deprun.Ready })
+		close(blip)
+		await(t, func() bool { return api.Load() == 2 })
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	for _, c := range []struct {
+		name       string
+		executions *atomic.Int32
+		want       int32
+	}{
+		{"db", db, 2},
+		{"repo", repo, 2},
+		{"api", api, 2},
+		{"metrics", metrics, 1},
+	} {
+		if have := c.executions.Load(); c.want != have {
+			t.Errorf("%s: want %d executions, have %d", c.name, c.want, have)
+		}
+	}
+}
+
+func TestRestartSubtreeExhausted(t *testing.T) {
+	var g deprun.Group
+
+	var failures atomic.Int32
+	myError := errors.New("down")
+	g.AddDepWith(func(deprun.ReadySignal) error {
+		failures.Add(1)
+		return myError
+	}, func(error) {}, deprun.RestartSubtree(deprun.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	}))
+
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) })
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := int32(3), failures.Load(); want != have {
+		t.Errorf("want %d failures, have %d", want, have)
+	}
+}
+
+func TestRestartSubtreeAddCtx(t *testing.T) {
+	var (
+		g    deprun.Group
+		blip = make(chan struct{})
+	)
+
+	execute, interrupt, _ := restartable(blip)
+	db := g.AddDepWith(execute, interrupt, deprun.Name("db"), deprun.RestartSubtree(deprun.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	}))
+
+	var consumers atomic.Int32
+	g.AddCtx(func(ctx context.Context) error {
+		consumers.Add(1)
+		<-ctx.Done()
+
+		return nil
+	}, db, deprun.Name("consumer"))
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		await(t, func() bool { return consumers.Load() == 1 })
+		close(blip)
+		await(t, func() bool { return consumers.Load() == 2 })
+
+		// The restarted consumer runs until the teardown.
+		time.Sleep(10 * time.Millisecond)
+		if want, have := deprun.Ready, g.States()[1].State; want != have {
+			t.Errorf("consumer: want %v, have %v", want, have)
+		}
+
+		return myError
+	}, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := int32(2), consumers.Load(); want != have {
+		t.Errorf("want %d consumer executions, have %d", want, have)
+	}
+}
@@ -0,0 +1,67 @@
+// Package systemd integrates a deprun.Group with the systemd service manager.
+// It implements the sd_notify protocol natively, so no cgo or libsystemd is
+// required. When the process is not started by systemd, i.e. NOTIFY_SOCKET
+// is unset, every function in this package is a no-op.
+package systemd
+
+import (
+	"net"
+	"os"
+	"sync"
+
+	"github.com/istovpets/deprun"
+)
+
+// Notify sends a state string, e.g. "READY=1", to the service manager.
+// It returns nil without doing anything if NOTIFY_SOCKET is unset.
+func Notify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	// A leading '@' denotes a socket in the abstract namespace.
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+
+	return err
+}
+
+// Notifier returns an actor, i.e. an execute and interrupt func, that sends
+// READY=1 when executed and STOPPING=1 when interrupted. It should only start
+// once the group is ready; Register wires that up.
+func Notifier() (execute func() error, interrupt func(error)) {
+	var (
+		once sync.Once
+		stop = make(chan struct{})
+	)
+	return func() error {
+			if err := Notify("READY=1"); err != nil {
+				return err
+			}
+			<-stop
+			return nil
+		}, func(error) {
+			once.Do(func() {
+				_ = Notify("STOPPING=1")
+				close(stop)
+			})
+		}
+}
+
+// Register adds a Notifier to g that reports READY=1 once every actor added
+// with AddDep is ready, and STOPPING=1 as soon as teardown begins. Use it with
+// units of Type=notify.
+func Register(g *deprun.Group) {
+	execute, interrupt := Notifier()
+	g.Add(execute, interrupt, g.Ready())
+}
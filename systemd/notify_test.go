@@ -0,0 +1,81 @@
+package systemd_test
+
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+	"github.com/istovpets/deprun/systemd"
+)
+
+func listen(t *testing.T) *net.UnixConn {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram not supported: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	t.Setenv("NOTIFY_SOCKET", path)
+
+	return conn
+}
+
+func read(t *testing.T, conn *net.UnixConn) string {
+	t.Helper()
+
+	buf := make([]byte, 256)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("read notification: %v", err)
+	}
+
+	return string(buf[:n])
+}
+
+func TestNotifyUnset(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if err := systemd.Notify("READY=1"); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	conn := listen(t)
+
+	var g deprun.Group
+	release := make(chan struct{})
+	g.AddDep(func(ready deprun.ReadySignal) error {
+		<-release
+		ready()
+		<-release
+		return nil
+	}, func(error) {})
+	myError := errors.New("teardown")
+	stop := make(chan struct{})
+	g.Add(func() error { <-stop; return myError }, func(error) {})
+	systemd.Register(&g)
+
+	res := make(chan error, 1)
+	go func() { res <- g.Run() }()
+
+	release <- struct{}{}
+	if want, have := "READY=1", read(t, conn); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	close(stop)
+	if want, have := "STOPPING=1", read(t, conn); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	close(release)
+
+	if want, have := myError, <-res; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,65 @@
+package systemd
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WatchdogInterval returns the watchdog timeout configured by the service
+// manager via WATCHDOG_USEC. It reports false if the watchdog is disabled or
+// intended for another process (WATCHDOG_PID).
+func WatchdogInterval() (time.Duration, bool) {
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, false
+	}
+
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(usec) * time.Microsecond, true
+}
+
+// Watchdog returns an actor, i.e. an execute and interrupt func, that sends
+// WATCHDOG=1 keepalives at half the configured watchdog interval. Before each
+// keepalive healthy is consulted; while it returns an error no keepalives are
+// sent, so systemd eventually considers the unit hung and restarts it
+// according to its Restart= setting. A nil healthy is always healthy.
+//
+// If the watchdog is not enabled the actor simply blocks until interrupted.
+// It terminates with nil on interrupt, or with the error of a failed send.
+func Watchdog(healthy func() error) (execute func() error, interrupt func(error)) {
+	var (
+		once sync.Once
+		stop = make(chan struct{})
+	)
+	return func() error {
+			timeout, ok := WatchdogInterval()
+			if !ok {
+				<-stop
+				return nil
+			}
+
+			ticker := time.NewTicker(timeout / 2)
+			defer ticker.Stop()
+
+			for {
+				if healthy == nil || healthy() == nil {
+					if err := Notify("WATCHDOG=1"); err != nil {
+						return err
+					}
+				}
+
+				select {
+				case <-ticker.C:
+				case <-stop:
+					return nil
+				}
+			}
+		}, func(error) {
+			once.Do(func() { close(stop) })
+		}
+}
@@ -0,0 +1,72 @@
+package systemd_test
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+	"github.com/istovpets/deprun/systemd"
+)
+
+func TestWatchdogInterval(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "3000000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+	if d, ok := systemd.WatchdogInterval(); !ok || d != 3*time.Second {
+		t.Errorf("want 3s, true; have %v, %v", d, ok)
+	}
+
+	t.Setenv("WATCHDOG_PID", "1")
+	if _, ok := systemd.WatchdogInterval(); ok {
+		t.Errorf("watchdog for another pid: want disabled")
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	conn := listen(t)
+	t.Setenv("WATCHDOG_USEC", "20000")
+
+	var healthy atomic.Bool
+	healthy.Store(true)
+
+	var g deprun.Group
+	g.Add(systemd.Watchdog(func() error {
+		if !healthy.Load() {
+			return errors.New("unhealthy")
+		}
+		return nil
+	}))
+	myError := errors.New("teardown")
+	stop := make(chan struct{})
+	g.Add(func() error { <-stop; return myError }, func(error) {})
+
+	res := make(chan error, 1)
+	go func() { res <- g.Run() }()
+
+	for range 3 {
+		if want, have := "WATCHDOG=1", read(t, conn); want != have {
+			t.Fatalf("want %q, have %q", want, have)
+		}
+	}
+
+	healthy.Store(false)
+	time.Sleep(30 * time.Millisecond) // let an in-flight keepalive land
+	_ = conn.SetReadDeadline(time.Now())
+	for {
+		if _, err := conn.Read(make([]byte, 64)); err != nil {
+			break
+		}
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	if n, err := conn.Read(make([]byte, 64)); err == nil {
+		t.Errorf("keepalive sent while unhealthy (%d bytes)", n)
+	}
+
+	close(stop)
+	if want, have := myError, <-res; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
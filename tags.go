@@ -0,0 +1,54 @@
+package deprun
+
+import "slices"
+
+// Tags labels an actor with tags, e.g. "storage", see DependsOnTag.
+func Tags(tags ...string) ActorOption {
+	return actorOption(func(a *actor) {
+		a.tags = append(a.tags, tags...)
+	})
+}
+
+// DependsOnTag makes an actor depend on every other actor tagged with tag,
+// whichever registration site added them: it starts once all of them are
+// ready. The tagged actors are looked up when the group runs, so they may
+// be added after the dependent one. A tag without actors is ready at once.
+// Two actors sharing a tag must not both depend on it.
+func DependsOnTag(tag string) ActorOption {
+	return actorOption(func(a *actor) {
+		a.tagDeps = append(a.tagDeps, tag)
+	})
+}
+
+// resolveTags returns a copy of actors where the dependencies of
+// DependsOnTag are replaced by the actors carrying the tags.
+func resolveTags(actors []actor) []actor {
+	var tagged bool
+	for i := range actors {
+		tagged = tagged || len(actors[i].tagDeps) > 0
+	}
+
+	if !tagged {
+		return actors
+	}
+
+	result := slices.Clone(actors)
+	for i := range result {
+		a := &result[i]
+		for _, tag := range a.tagDeps {
+			for j := range actors {
+				if j != i && slices.Contains(actors[j].tags, tag) {
+					a.dependsOn = append(slices.Clip(a.dependsOn), actors[j].provides)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// graph returns the registered actors with their dependencies resolved as
+// they will be when the group runs, startup phases aside.
+func (g *Group) graph() []actor {
+	return resolveTags(g.registered())
+}
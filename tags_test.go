@@ -0,0 +1,59 @@
+package deprun_test
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestDependsOnTag(t *testing.T) {
+	var g deprun.Group
+
+	var ready atomic.Int32
+	stop := make(chan struct{})
+	storage := func(name string) {
+		g.AddDepWith(func(signal deprun.ReadySignal) error {
+			ready.Add(1)
+			signal()
+			<-stop
+
+			return nil
+		}, nil, deprun.Name(name), deprun.Tags("storage"))
+	}
+
+	myError := errors.New("done")
+	g.AddWith(func() error {
+		if want, have := int32(2), ready.Load(); want != have {
+			return errors.New("started before storage was ready")
+		}
+
+		return myError
+	}, nil, deprun.DependsOnTag("storage"), deprun.Name("api"))
+	g.Add(func() error { <-stop; return nil }, func(error) { close(stop) })
+
+	// Tagged actors may be added after their dependents.
+	storage("db")
+	storage("cache")
+
+	if want, have := []string{"db", "cache"}, g.Snapshot().Actors[0].DependsOn; !slices.Equal(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestDependsOnTagEmpty(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("done")
+	g.AddWith(func() error { return myError }, nil, deprun.DependsOnTag("nothing"))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
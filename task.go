@@ -0,0 +1,84 @@
+package deprun
+
+import "context"
+
+// AddTask adds a run-to-completion actor and returns a Dependency that
+// becomes ready once task returns nil, i.e. once it has completed rather than
+// merely started. A typical use is a database migration that must finish
+// before the API server starts:
+//
+//	migrated := g.AddTask(migrate)
+//	g.Add(serve, stop, migrated)
+//
+// A task returning nil does not tear down the group. A task returning an
+// error does, unless it is NonCritical; its dependents never start. The
+// context passed to task is canceled when the task is interrupted, with the
+// teardown error as its cause, see context.Cause.
+func (g *Group) AddTask(task func(ctx context.Context) error, opts ...ActorOption) *Dependency {
+	return g.add(actor{ctxExecute: task, task: true}, opts)
+}
+
+// Result is a Dependency on a task that produces a value of type T. It can
+// be passed to Add and the other methods accepting actor options, like any
+// Dependency.
+type Result[T any] struct {
+	*Dependency
+	value T
+}
+
+// Value returns the value produced by the task. It must only be called once
+// the Result is ready, typically from an actor depending on it; before that
+// it returns the zero value of T.
+func (r *Result[T]) Value() T {
+	if !r.isReady() {
+		var zero T
+
+		return zero
+	}
+
+	return r.value
+}
+
+// AddTaskResult is like AddTask, but task produces a value that is passed to
+// the actors depending on the returned Result:
+//
+//	cfg := deprun.AddTaskResult(&g, loadConfig)
+//	db := deprun.AddTaskResult(&g, func(ctx context.Context) (*sql.DB, error) {
+//		return connect(ctx, cfg.Value().DSN)
+//	}, cfg)
+//
+// The value is discarded if task returns an error.
+func AddTaskResult[T any](g *Group, task func(ctx context.Context) (T, error), opts ...ActorOption) *Result[T] {
+	r := &Result[T]{}
+	r.Dependency = g.AddTask(func(ctx context.Context) error {
+		v, err := task(ctx)
+		if err != nil {
+			return err
+		}
+
+		r.value = v
+
+		return nil
+	}, opts...)
+
+	return r
+}
+
+// WithRunToCompletion runs the group as a DAG of finite jobs rather than a
+// set of long-running services, typically built with AddTask:
+//
+//   - an actor returning, with or without an error, never tears the group
+//     down;
+//   - an actor that fails, or exits before it is ready, cancels its
+//     downstream: actors depending on it, directly or transitively, never
+//     start, while independent branches keep running;
+//   - Run returns once every actor has returned or was skipped, with the
+//     errors of all failed actors joined, each prefixed by the actor's name.
+//
+// Failures of external dependencies, such as a startup timeout, still tear
+// the group down.
+func WithRunToCompletion() Option {
+	return func(g *Group) {
+		g.runToCompletion = true
+	}
+}
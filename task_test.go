@@ -0,0 +1,173 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestAddTask(t *testing.T) {
+	var g deprun.Group
+	var migrated atomic.Bool
+	done := g.AddTask(func(context.Context) error {
+		time.Sleep(5 * time.Millisecond)
+		migrated.Store(true)
+		return nil
+	})
+
+	myError := errors.New("server stopped")
+	g.Add(func() error {
+		if !migrated.Load() {
+			t.Error("server started before migration completed")
+		}
+		return myError
+	}, func(error) {}, done)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestAddTaskFailure(t *testing.T) {
+	var g deprun.Group
+	myError := errors.New("migration failed")
+	done := g.AddTask(func(context.Context) error { return myError })
+	g.Add(func() error {
+		t.Error("dependent started after failed task")
+		return nil
+	}, func(error) {}, done)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestAddTaskInterrupted(t *testing.T) {
+	var g deprun.Group
+	g.AddTask(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	myError := errors.New("teardown")
+	g.Add(func() error { return myError }, func(error) {})
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestRunToCompletion(t *testing.T) {
+	g := deprun.New(deprun.WithRunToCompletion())
+
+	var order []string
+	var mu sync.Mutex
+	step := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	extract := g.AddTask(step("extract"), deprun.Name("extract"))
+	transform := g.AddTask(step("transform"), extract, deprun.Name("transform"))
+	g.AddTask(step("load"), transform, deprun.Name("load"))
+
+	if err := g.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want, have := "extract transform load", strings.Join(order, " "); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestRunToCompletionFailure(t *testing.T) {
+	g := deprun.New(deprun.WithRunToCompletion())
+
+	myError := errors.New("extract failed")
+	extract := g.AddTask(func(context.Context) error { return myError }, deprun.Name("extract"))
+	g.AddTask(func(context.Context) error {
+		t.Error("downstream task started after failed task")
+		return nil
+	}, extract)
+
+	var independent atomic.Bool
+	g.AddTask(func(context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		independent.Store(true)
+		return nil
+	})
+
+	err := g.Run()
+	if !errors.Is(err, myError) {
+		t.Errorf("want %v, have %v", myError, err)
+	}
+
+	if want, have := "extract: extract failed", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if !independent.Load() {
+		t.Error("independent task did not complete")
+	}
+}
+
+func TestRunToCompletionJoinsErrors(t *testing.T) {
+	g := deprun.New(deprun.WithRunToCompletion())
+
+	errA, errB := errors.New("a"), errors.New("b")
+	g.AddTask(func(context.Context) error { return errA })
+	g.AddTask(func(context.Context) error { return errB })
+
+	err := g.Run()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("want both errors, have %v", err)
+	}
+}
+
+func TestAddTaskResult(t *testing.T) {
+	g := deprun.New(deprun.WithRunToCompletion())
+
+	type config struct{ DSN string }
+	cfg := deprun.AddTaskResult(g, func(context.Context) (config, error) {
+		return config{DSN: "postgres://db"}, nil
+	})
+	if want, have := "", cfg.Value().DSN; want != have {
+		t.Errorf("before run: want %q, have %q", want, have)
+	}
+
+	dsn := deprun.AddTaskResult(g, func(context.Context) (string, error) {
+		return cfg.Value().DSN, nil
+	}, cfg)
+
+	if err := g.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want, have := "postgres://db", dsn.Value(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestAddTaskResultFailure(t *testing.T) {
+	g := deprun.New(deprun.WithRunToCompletion())
+
+	myError := errors.New("no config")
+	cfg := deprun.AddTaskResult(g, func(context.Context) (int, error) { return 42, myError })
+
+	if err := g.Run(); !errors.Is(err, myError) {
+		t.Errorf("want %v, have %v", myError, err)
+	}
+
+	if want, have := 0, cfg.Value(); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
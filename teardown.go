@@ -0,0 +1,29 @@
+package deprun
+
+// Exit describes an actor that has returned, see WithTeardownWhen.
+type Exit struct {
+	Name string // see Name
+	Err  error  // the error returned by the actor, after error filtering
+}
+
+// WithTeardownWhen replaces the rule deciding when the group is torn down.
+// After every exit of an actor that is not NonCritical, when is called with
+// all such exits so far, in order, and the total number of such actors in
+// the group. Teardown begins once it returns true. If it never does, the
+// group stops when every actor has returned.
+//
+// WithTeardownWhen takes precedence over WithWaitAll.
+func WithTeardownWhen(when func(exits []Exit, total int) bool) Option {
+	return func(g *Group) {
+		g.teardownWhen = when
+	}
+}
+
+// WithQuorum tears the group down once n actors have exited, e.g. to let
+// redundant consumers finish individually but stop the group when a
+// majority of them is gone.
+func WithQuorum(n int) Option {
+	return WithTeardownWhen(func(exits []Exit, _ int) bool {
+		return len(exits) >= n
+	})
+}
@@ -0,0 +1,62 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestQuorum(t *testing.T) {
+	g := deprun.New(deprun.WithQuorum(2))
+	stop := make(chan struct{})
+	consumer := func(d time.Duration, err error) func() error {
+		return func() error {
+			select {
+			case <-time.After(d):
+				return err
+			case <-stop:
+				return nil
+			}
+		}
+	}
+	myError := errors.New("consumer failed")
+	g.Add(consumer(time.Millisecond, nil), func(error) {})
+	g.Add(consumer(10*time.Millisecond, myError), func(error) {})
+	g.Add(consumer(time.Hour, nil), func(error) { close(stop) })
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestTeardownWhen(t *testing.T) {
+	var seen []deprun.Exit
+	g := deprun.New(deprun.WithTeardownWhen(func(exits []deprun.Exit, total int) bool {
+		seen = exits
+		return len(exits) > total/2
+	}))
+	stop := make(chan struct{})
+	for i, name := range []string{"a", "b", "c"} {
+		g.AddWith(func() error {
+			if i == 2 {
+				<-stop
+				return nil
+			}
+			time.Sleep(time.Duration(i+1) * 5 * time.Millisecond)
+			return nil
+		}, func(error) {
+			if i == 2 {
+				close(stop)
+			}
+		}, deprun.Name(name))
+	}
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+	if len(seen) != 2 || seen[0].Name != "a" || seen[1].Name != "b" {
+		t.Errorf("unexpected exits %+v", seen)
+	}
+}
@@ -0,0 +1,111 @@
+package deprun
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Interval is a period an actor spent in a state, see Timeline.
+type Interval struct {
+	Actor string
+	State ActorState
+	Start time.Time
+	End   time.Time // zero if the actor is still in the state
+}
+
+// Timeline returns the intervals the actors spent waiting for their
+// dependencies, starting, ready and stopping during the current or last
+// run, ordered by actor and then by time. It is the data of a Gantt chart
+// of the run; see WriteChromeTrace for a ready-made rendering.
+func (g *Group) Timeline() []Interval {
+	var intervals []Interval
+
+	actors := g.registered()
+	for i := range actors {
+		times, _ := actors[i].history()
+
+		for s := WaitingDeps; s < Stopped; s++ {
+			if times[s].IsZero() {
+				continue
+			}
+
+			in := Interval{Actor: actors[i].String(), State: s, Start: times[s]}
+			for next := s + 1; next <= Stopped; next++ {
+				if !times[next].IsZero() {
+					in.End = times[next]
+
+					break
+				}
+			}
+
+			intervals = append(intervals, in)
+		}
+	}
+
+	return intervals
+}
+
+// traceEvent is an event of the Chrome trace event format.
+type traceEvent struct {
+	Name string            `json:"name"`
+	Cat  string            `json:"cat,omitempty"`
+	Ph   string            `json:"ph"`
+	Ts   int64             `json:"ts"`
+	Dur  int64             `json:"dur,omitempty"`
+	Pid  int               `json:"pid"`
+	Tid  int               `json:"tid"`
+	Args map[string]string `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the Timeline to w in the Chrome trace event
+// format, which chrome://tracing and Perfetto render as a Gantt chart with
+// a row per actor. Intervals still in progress end at the time of the call.
+func (g *Group) WriteChromeTrace(w io.Writer) error {
+	now := g.clockOrSystem().Now()
+	intervals := g.Timeline()
+
+	var origin time.Time
+	for _, in := range intervals {
+		if origin.IsZero() || in.Start.Before(origin) {
+			origin = in.Start
+		}
+	}
+
+	events := []traceEvent{}
+	tids := make(map[string]int)
+	for _, in := range intervals {
+		tid, ok := tids[in.Actor]
+		if !ok {
+			tid = len(tids) + 1
+			tids[in.Actor] = tid
+			events = append(events, traceEvent{
+				Name: "thread_name",
+				Ph:   "M",
+				Pid:  1,
+				Tid:  tid,
+				Args: map[string]string{"name": in.Actor},
+			})
+		}
+
+		end := in.End
+		if end.IsZero() {
+			end = now
+		}
+
+		events = append(events, traceEvent{
+			Name: in.State.String(),
+			Cat:  "deprun",
+			Ph:   "X",
+			Ts:   in.Start.Sub(origin).Microseconds(),
+			Dur:  end.Sub(in.Start).Microseconds(),
+			Pid:  1,
+			Tid:  tid,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
@@ -0,0 +1,76 @@
+package deprun_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestTimeline(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		time.Sleep(10 * time.Millisecond)
+		ready()
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("db"))
+
+	myError := errors.New("done")
+	g.AddWith(func() error { return myError }, nil, db, deprun.Name("api"))
+	g.Run()
+
+	var have []string
+	for _, in := range g.Timeline() {
+		if in.End.Before(in.Start) {
+			t.Errorf("%s %s: ends before it starts", in.Actor, in.State)
+		}
+
+		have = append(have, in.Actor+": "+in.State.String())
+	}
+
+	want := []string{
+		"db: waiting for dependencies",
+		"db: running",
+		"db: ready",
+		"db: stopping",
+		"api: waiting for dependencies",
+		"api: running",
+		"api: ready",
+	}
+	if strings.Join(want, "\n") != strings.Join(have, "\n") {
+		t.Errorf("want\n%s\nhave\n%s", strings.Join(want, "\n"), strings.Join(have, "\n"))
+	}
+
+	var b strings.Builder
+	if err := g.WriteChromeTrace(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	var trace struct {
+		TraceEvents []struct {
+			Name string
+			Ph   string
+			Dur  int64
+			Tid  int
+		}
+	}
+
+	if err := json.Unmarshal([]byte(b.String()), &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 2+len(want), len(trace.TraceEvents); want != have {
+		t.Fatalf("want %d events, have %d", want, have)
+	}
+
+	if e := trace.TraceEvents[2]; e.Name != "running" || e.Dur < (10*time.Millisecond).Microseconds() {
+		t.Errorf("want db running for at least 10ms, have %+v", e)
+	}
+}
@@ -0,0 +1,78 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrActorTimeout is matched, via errors.Is, by the error of an actor that
+// exceeded its Timeout.
+var ErrActorTimeout = errors.New("actor timeout")
+
+// ActorTimeoutError is the error of an actor that did not return within its
+// Timeout.
+type ActorTimeoutError struct {
+	Actor   string // its name or, if unnamed, its index and call site
+	Timeout time.Duration
+}
+
+// Error implements the error interface.
+func (e *ActorTimeoutError) Error() string {
+	return fmt.Sprintf("deprun: %s: timed out after %v", e.Actor, e.Timeout)
+}
+
+// Is makes errors.Is(err, ErrActorTimeout) report true.
+func (e *ActorTimeoutError) Is(target error) bool {
+	return target == ErrActorTimeout
+}
+
+// Timeout bounds the runtime of an actor: if execute has not returned
+// within d of its start, the actor is interrupted with an
+// *ActorTimeoutError, which then replaces the error it returns. That error
+// tears the group down, unless the actor is NonCritical. The interrupt
+// function is not called again on teardown.
+func Timeout(d time.Duration) ActorOption {
+	return actorOption(func(a *actor) {
+		a.timeout = d
+	})
+}
+
+// startTimeout interrupts a if it has not returned within its timeout. The
+// returned stop function must be called once execute returns; it reports
+// whether the timeout expired. Whichever of stop and the timer comes first
+// decides, so a timer firing after execute returned does not turn a clean
+// exit into a timeout.
+func (g *Group) startTimeout(a *actor) (stop func() (expired bool)) {
+	if a.timeout <= 0 {
+		return func() bool { return false }
+	}
+
+	const (
+		running int32 = iota
+		returned
+		expired
+	)
+
+	var state atomic.Int32
+	timer := a.clock.AfterFunc(a.timeout, func() {
+		if state.CompareAndSwap(running, expired) {
+			g.interruptActor(a, a.timeoutError())
+		}
+	})
+
+	return func() bool {
+		if state.CompareAndSwap(running, returned) {
+			timer.Stop()
+
+			return false
+		}
+
+		return true
+	}
+}
+
+func (a *actor) timeoutError() error {
+	return &ActorTimeoutError{Actor: a.qualified(), Timeout: a.timeout}
+}
@@ -0,0 +1,110 @@
+package deprun_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestTimeout(t *testing.T) {
+	var g deprun.Group
+
+	stop := make(chan struct{})
+	g.AddWith(func() error {
+		<-stop
+		return nil
+	}, func(error) { close(stop) }, deprun.Name("slow"), deprun.Timeout(10*time.Millisecond))
+
+	quit := make(chan struct{})
+	g.Add(func() error {
+		<-quit
+		return nil
+	}, func(error) { close(quit) })
+
+	err := g.Run()
+	if !errors.Is(err, deprun.ErrActorTimeout) {
+		t.Fatalf("want ErrActorTimeout, have %v", err)
+	}
+
+	var timeoutErr *deprun.ActorTimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Actor != "slow" {
+		t.Errorf("want the timeout of slow, have %v", err)
+	}
+}
+
+func TestTimeoutNonCritical(t *testing.T) {
+	var g deprun.Group
+
+	var interruptErr error
+	stop := make(chan struct{})
+	g.AddWith(func() error {
+		<-stop
+		return nil
+	}, func(err error) {
+		interruptErr = err
+		close(stop)
+	}, deprun.Timeout(10*time.Millisecond), deprun.NonCritical())
+
+	myError := errors.New("done")
+	g.Add(func() error {
+		<-stop
+		time.Sleep(10 * time.Millisecond) // the group keeps running
+		return myError
+	}, nil)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if !errors.Is(interruptErr, deprun.ErrActorTimeout) {
+		t.Errorf("want interrupt with ErrActorTimeout, have %v", interruptErr)
+	}
+}
+
+func TestTimeoutNotExpired(t *testing.T) {
+	var g deprun.Group
+
+	myError := errors.New("done")
+	g.AddWith(func() error { return myError }, func(error) {}, deprun.Timeout(time.Minute))
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+// lateClock is a fakeClock whose AfterFunc timers fire when stopped, as a
+// timer expiring right after execute returned would.
+type lateClock struct{ *fakeClock }
+
+func (c lateClock) AfterFunc(_ time.Duration, f func()) deprun.Timer { return lateTimer{f} }
+
+type lateTimer struct{ f func() }
+
+func (lateTimer) C() <-chan time.Time { return nil }
+
+func (t lateTimer) Stop() bool {
+	t.f()
+
+	return false
+}
+
+func (lateTimer) Reset(time.Duration) bool { return false }
+
+func TestTimeoutRacingReturn(t *testing.T) {
+	g := deprun.New(deprun.WithClock(lateClock{&fakeClock{now: time.Unix(0, 0)}}))
+
+	var interrupted bool
+	g.AddWith(func() error { return nil }, func(err error) {
+		interrupted = errors.Is(err, deprun.ErrActorTimeout)
+	}, deprun.Timeout(time.Second))
+
+	if err := g.Run(); err != nil {
+		t.Errorf("want no error, have %v", err)
+	}
+
+	if interrupted {
+		t.Error("want no interrupt with ErrActorTimeout")
+	}
+}
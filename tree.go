@@ -0,0 +1,74 @@
+package deprun
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String renders the dependency graph of the group as an indented tree,
+// for quick debugging: the actors without dependencies on other actors are
+// the roots, and each actor is followed by the actors depending on it, with
+// their current states. An actor depending on several actors appears under
+// each of them, but its dependents are only listed the first time.
+func (g *Group) String() string {
+	actors := g.graph()
+	providers := providerIndices(actors)
+
+	var (
+		roots      []int
+		dependents = make(map[int][]int)
+		external   = make(map[int]bool)
+	)
+
+	for i := range actors {
+		deps, ext := actors[i].edges(providers)
+		if len(deps) == 0 {
+			roots = append(roots, i)
+		}
+
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], i)
+		}
+
+		external[i] = ext
+	}
+
+	var (
+		b        strings.Builder
+		expanded = make(map[int]bool)
+		write    func(i, depth int)
+	)
+
+	write = func(i, depth int) {
+		a := &actors[i]
+		fmt.Fprintf(&b, "%s%s: %s", strings.Repeat("  ", depth), a, a.currentState())
+
+		if a.paused() {
+			b.WriteString(" (paused)")
+		}
+
+		if external[i] {
+			b.WriteString(" (and external dependencies)")
+		}
+
+		if expanded[i] && len(dependents[i]) > 0 {
+			b.WriteString(" ...\n")
+
+			return
+		}
+
+		b.WriteString("\n")
+		expanded[i] = true
+
+		for _, d := range dependents[i] {
+			write(d, depth+1)
+		}
+	}
+
+	fmt.Fprintf(&b, "deprun: group %s\n", groupStatuses[g.status.Load()])
+	for _, i := range roots {
+		write(i, 1)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package deprun_test
+
+import (
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestString(t *testing.T) {
+	var (
+		other deprun.Group
+		g     deprun.Group
+	)
+
+	nop := func(deprun.ReadySignal) error { return nil }
+	db := g.AddDepWith(nop, nil, deprun.Name("db"))
+	cache := g.AddDepWith(nop, nil, db, deprun.Name("cache"))
+	worker := g.AddDepWith(nop, nil, db, cache, deprun.Name("worker"))
+	g.AddWith(func() error { return nil }, nil, worker, deprun.Name("reporter"))
+	g.AddWith(func() error { return nil }, nil, other.Ready(), deprun.Name("api"))
+
+	want := `deprun: group idle
+  db: pending
+    cache: pending
+      worker: pending
+        reporter: pending
+    worker: pending ...
+  api: pending (and external dependencies)
+`
+	if have := g.String(); want != have {
+		t.Errorf("want\n%s\nhave\n%s", want, have)
+	}
+}
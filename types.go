@@ -1,6 +1,10 @@
 package deprun
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
 
 // ReadySignal is a function that must be called by an actor to signal that
 // it is ready. This will unblock any actors that depend on it.
@@ -10,35 +14,184 @@ type ReadySignal func()
 // It is a signaling mechanism that ensures an actor only starts after its
 // dependencies are ready. A Dependency is returned by AddDep and can be
 // passed to Add.
+//
+// A Dependency is resolved at most once: it becomes ready, or it fails or is
+// interrupted if its provider exits before it is ready. Its methods are safe
+// for concurrent use.
 type Dependency struct {
-	once        sync.Once
-	ch          chan struct{}
-	interrupted bool
+	once  sync.Once
+	ch    chan struct{} // closed once resolved
+	state atomic.Int32  // a DependencyState, set before ch is closed
+	err   error         // see Err, set before ch is closed
+
+	// source, if set, resolves a dependency that is not provided by any
+	// actor of the group. Run executes it as a hidden actor, so that it
+	// participates in teardown like any other actor.
+	source     func(ctx context.Context, ready ReadySignal) error
+	sourceDeps []*Dependency // the hidden actor's dependencies
+	quorum     int           // if set, the source starts once quorum of sourceDeps are ready, see AtLeast
+
+	rearm *rearm // set for dependencies of AddRearmable
+
+	demandOnce sync.Once
+	demanded   chan struct{} // closed once demanded, see Lazy
+	idle       *idle         // set for providers with an IdleTimeout
+
+	mu        sync.Mutex
+	notified  bool     // set once resolved callbacks may no longer be queued
+	callbacks []func() // called once resolved, see whenResolved
+}
+
+// DependencyState is the state of a Dependency.
+type DependencyState int32
+
+// States of a Dependency. All but DependencyPending are final.
+const (
+	DependencyPending     DependencyState = iota // not resolved yet
+	DependencyReady                              // the provider signaled ready
+	DependencyFailed                             // the provider failed before it was ready, see Dependency.Err
+	DependencyInterrupted                        // the provider stopped before it was ready, e.g. on teardown
+)
+
+var dependencyStates = [...]string{
+	DependencyPending:     "pending",
+	DependencyReady:       "ready",
+	DependencyFailed:      "failed",
+	DependencyInterrupted: "interrupted",
+}
+
+func (s DependencyState) String() string {
+	if s < 0 || int(s) >= len(dependencyStates) {
+		return "unknown"
+	}
+
+	return dependencyStates[s]
 }
 
 func newDependency() *Dependency {
 	return &Dependency{
-		ch: make(chan struct{}),
+		ch:       make(chan struct{}),
+		demanded: make(chan struct{}),
 	}
 }
 
+// externalDependency returns a Dependency resolved by source instead of an
+// actor. The source starts once dependsOn are ready; it should call ready
+// once the dependency is satisfied, and return early when ctx is canceled.
+// A non-nil error from source, returned before ctx is canceled, tears down
+// the group like an actor error.
+func externalDependency(source func(ctx context.Context, ready ReadySignal) error, dependsOn ...*Dependency) *Dependency {
+	d := newDependency()
+	d.source = source
+	d.sourceDeps = dependsOn
+
+	return d
+}
+
+// State returns the current state of the dependency, without blocking.
+func (s *Dependency) State() DependencyState {
+	return DependencyState(s.state.Load())
+}
+
+// Done returns a channel that is closed once the dependency is resolved,
+// i.e. no longer DependencyPending.
+func (s *Dependency) Done() <-chan struct{} {
+	return s.ch
+}
+
+// Err returns the error of the provider if the dependency failed, and nil
+// otherwise.
+func (s *Dependency) Err() error {
+	select {
+	case <-s.ch:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Wait blocks until the dependency is resolved or ctx is done, and returns
+// its state. If ctx is done first, it returns DependencyPending and
+// context.Cause(ctx); if the dependency failed, the error of its provider.
+func (s *Dependency) Wait(ctx context.Context) (DependencyState, error) {
+	s.Demand()
+
+	select {
+	case <-s.ch:
+		return s.State(), s.err
+	case <-ctx.Done():
+		return DependencyPending, context.Cause(ctx)
+	}
+}
+
+// wait blocks until the dependency is resolved and reports whether it is
+// ready.
 func (s *Dependency) wait() bool {
 	<-s.ch
 
-	return !s.interrupted
+	return s.State() == DependencyReady
+}
+
+// isReady reports whether the dependency became ready, without blocking.
+func (s *Dependency) isReady() bool {
+	return s.State() == DependencyReady
+}
+
+// resolved reports whether the dependency became ready, failed or was
+// interrupted, without blocking.
+func (s *Dependency) resolved() bool {
+	return s.State() != DependencyPending
 }
 
 // ready resolves the dependency and unblocks dependents.
 // It is optional: a dependency may never become ready.
 func (s *Dependency) ready() {
-	s.once.Do(func() {
-		close(s.ch)
-	})
+	s.resolve()
+}
+
+// resolve is like ready, and reports whether this call resolved the
+// dependency.
+func (s *Dependency) resolve() bool {
+	return s.finish(DependencyReady, nil)
+}
+
+// fail resolves the dependency as failed with err, or as interrupted if err
+// is nil.
+func (s *Dependency) fail(err error) {
+	if err == nil {
+		s.interrupt()
+
+		return
+	}
+
+	s.finish(DependencyFailed, err)
+
+	if s.rearm != nil {
+		s.rearm.stop()
+	}
 }
 
 func (s *Dependency) interrupt() {
+	s.finish(DependencyInterrupted, nil)
+
+	if s.rearm != nil {
+		s.rearm.stop()
+	}
+}
+
+// finish resolves the dependency with state and err, unless it is already
+// resolved, and reports whether it did.
+func (s *Dependency) finish(state DependencyState, err error) (resolved bool) {
 	s.once.Do(func() {
-		s.interrupted = true
+		s.err = err
+		s.state.Store(int32(state))
 		close(s.ch)
+		resolved = true
 	})
+
+	if resolved {
+		s.notify()
+	}
+
+	return resolved
 }
@@ -0,0 +1,161 @@
+package deprun
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Errors matched, via errors.Is, by the errors Validate returns.
+var (
+	ErrCycle             = errors.New("dependency cycle")
+	ErrUnknownDependency = errors.New("dependency not provided by the group")
+	ErrDuplicateName     = errors.New("duplicate actor name")
+)
+
+// Validate checks the wiring of the group without running anything, e.g. in
+// a unit test of the code building it. It reports, joined:
+//
+//   - dependency cycles, startup phases included, matching ErrCycle, as
+//     "a -> b -> a" where a depends on b;
+//   - dependencies that no actor of the group provides, directly or through
+//     external dependencies such as Probe, matching ErrUnknownDependency.
+//     A dependency provided by another group counts as unknown;
+//   - actors with a nil execute function, which never become ready,
+//     matching ErrDependencyNeverReady if others may depend on them;
+//   - names given to several actors, matching ErrDuplicateName.
+//
+// It returns nil if the group is well-formed.
+func (g *Group) Validate() error {
+	actors := phased(g.graph())
+	providers := providerIndices(actors)
+
+	var errs []error
+
+	names := make(map[string]int)
+	for i := range actors {
+		a := &actors[i]
+		if a.name != "" {
+			if names[a.name]++; names[a.name] == 2 {
+				errs = append(errs, fmt.Errorf("deprun: %s: %w", a.name, ErrDuplicateName))
+			}
+		}
+
+		if a.execute == nil && a.rearmable == nil && a.beating == nil && a.ctxExecute == nil {
+			if a.provider {
+				errs = append(errs, fmt.Errorf("deprun: %s: nil execute function: %w", a.qualified(), ErrDependencyNeverReady))
+			} else {
+				errs = append(errs, fmt.Errorf("deprun: %s: nil execute function", a.qualified()))
+			}
+		}
+
+		if n := unknownDependencies(a, providers); n > 0 {
+			errs = append(errs, fmt.Errorf("deprun: %s: %d %w", a.qualified(), n, ErrUnknownDependency))
+		}
+	}
+
+	for _, cycle := range cycles(actors, providers) {
+		errs = append(errs, cycleError(actors, cycle))
+	}
+
+	return errors.Join(errs...)
+}
+
+// unknownDependencies returns how many of the dependencies of a, direct or
+// through external dependencies, neither an actor nor an external source
+// provides.
+func unknownDependencies(a *actor, providers map[*Dependency]int) int {
+	var (
+		n    int
+		seen = make(map[*Dependency]bool)
+		walk func(d *Dependency)
+	)
+
+	walk = func(d *Dependency) {
+		if d == nil || seen[d] {
+			return
+		}
+
+		seen[d] = true
+
+		if _, ok := providers[d]; ok {
+			return
+		}
+
+		if d.source == nil {
+			n++
+
+			return
+		}
+
+		for _, dep := range d.sourceDeps {
+			walk(dep)
+		}
+	}
+
+	for _, d := range a.dependsOn {
+		walk(d)
+	}
+
+	return n
+}
+
+// cycleError describes a cycle returned by cycles.
+func cycleError(actors []actor, cycle []int) error {
+	names := make([]string, 0, len(cycle)+1)
+	for _, i := range cycle {
+		names = append(names, actors[i].String())
+	}
+	names = append(names, names[0])
+
+	return fmt.Errorf("deprun: %w: %s", ErrCycle, strings.Join(names, " -> "))
+}
+
+// cycles returns the dependency cycles among actors, each as the indices of
+// its actors, every actor depending on the next and the last on the first.
+func cycles(actors []actor, providers map[*Dependency]int) [][]int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		result [][]int
+		color  = make([]int, len(actors))
+		stack  []int
+		visit  func(i int)
+	)
+
+	visit = func(i int) {
+		color[i] = visiting
+		stack = append(stack, i)
+
+		deps, _ := actors[i].edges(providers)
+		for _, dep := range deps {
+			switch color[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				start := len(stack) - 1
+				for stack[start] != dep {
+					start--
+				}
+
+				result = append(result, slices.Clone(stack[start:]))
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		color[i] = visited
+	}
+
+	for i := range actors {
+		if color[i] == unvisited {
+			visit(i)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,65 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestValidate(t *testing.T) {
+	var g deprun.Group
+
+	db := g.AddDepWith(func(ready deprun.ReadySignal) error {
+		ready()
+		return nil
+	}, func(error) {}, deprun.Name("db"))
+	g.AddWith(func() error { return nil }, func(error) {}, db, deprun.Probe(func(context.Context) error { return nil }), deprun.Name("api"))
+	g.Add(func() error { return nil }, func(error) {})
+	g.Phase(1).AddWith(func() error { return nil }, func(error) {}, deprun.Name("worker"))
+
+	if err := g.Validate(); err != nil {
+		t.Errorf("want no error, have %v", err)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	var (
+		g     deprun.Group
+		other deprun.Group
+	)
+
+	noop := func(error) {}
+	ready := func(ready deprun.ReadySignal) error {
+		ready()
+		return nil
+	}
+
+	// a depends on b by tag, and b on a.
+	a := g.AddDepWith(ready, noop, deprun.Name("a"), deprun.DependsOnTag("b"))
+	g.AddDepWith(ready, noop, deprun.Name("b"), deprun.Tags("b"), a)
+
+	g.AddDepWith(nil, noop, deprun.Name("nil"))
+	g.AddWith(func() error { return nil }, noop, other.AddDep(ready, noop), deprun.Name("foreign"))
+	g.AddWith(func() error { return nil }, noop, deprun.Name("a"))
+
+	err := g.Validate()
+	for _, want := range []error{deprun.ErrCycle, deprun.ErrDependencyNeverReady, deprun.ErrUnknownDependency, deprun.ErrDuplicateName} {
+		if !errors.Is(err, want) {
+			t.Errorf("want %v in %v", want, err)
+		}
+	}
+
+	for _, want := range []string{
+		"deprun: dependency cycle: a -> b -> a",
+		"deprun: a: duplicate actor name",
+		"deprun: nil: nil execute function",
+		"deprun: foreign: 1 dependency not provided by the group",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("want %q in %q", want, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package deprun
+
+import (
+	"context"
+	"sync"
+)
+
+// Value is a Dependency carrying a value that its provider may update over
+// time, e.g. rotated credentials or refreshed configuration. It can be passed
+// to Add and the other methods accepting actor options, like any Dependency.
+type Value[T any] struct {
+	*Dependency
+
+	mu      sync.Mutex
+	value   T
+	changed chan struct{} // closed and replaced on every publish
+}
+
+// AddValue adds an actor providing a Value. execute calls publish with the
+// first value once it is available, which makes the Value ready, and again
+// whenever the value changes:
+//
+//	creds := deprun.AddValue(&g, func(publish func(Credentials)) error {
+//		return vault.Watch(ctx, publish)
+//	}, stop)
+//	g.Add(func() error {
+//		return serve(creds.Watch(ctx))
+//	}, nil, creds)
+func AddValue[T any](g *Group, execute func(publish func(T)) error, interrupt func(error), opts ...ActorOption) *Value[T] {
+	v := &Value[T]{changed: make(chan struct{})}
+	v.Dependency = g.AddDepWith(func(ready ReadySignal) error {
+		return execute(func(value T) {
+			v.publish(value)
+			ready()
+		})
+	}, interrupt, opts...)
+
+	return v
+}
+
+func (v *Value[T]) publish(value T) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.value = value
+	close(v.changed)
+	v.changed = make(chan struct{})
+}
+
+// Load returns the latest published value, or the zero value of T if none
+// was published yet.
+func (v *Value[T]) Load() T {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.value
+}
+
+// Watch returns a channel receiving the latest value once the Value is
+// ready, and then every update. A slow receiver only gets the latest value:
+// intermediate updates are skipped. The channel is closed when ctx is done.
+func (v *Value[T]) Watch(ctx context.Context) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		select {
+		case <-v.Done():
+		case <-ctx.Done():
+			return
+		}
+
+		if !v.isReady() {
+			<-ctx.Done()
+
+			return
+		}
+
+		for {
+			v.mu.Lock()
+			value, changed := v.value, v.changed
+			v.mu.Unlock()
+
+			select {
+			case out <- value:
+			case <-changed:
+				continue // a newer value replaces the unsent one
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,61 @@
+package deprun_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/istovpets/deprun"
+)
+
+func TestAddValue(t *testing.T) {
+	var g deprun.Group
+
+	var (
+		next = make(chan struct{})
+		stop = make(chan struct{})
+	)
+
+	config := deprun.AddValue(&g, func(publish func(string)) error {
+		publish("v1")
+		<-next
+		publish("v2")
+		<-stop
+
+		return nil
+	}, func(error) { close(stop) })
+
+	if have := config.Load(); have != "" {
+		t.Errorf("want no value before Run, have %q", have)
+	}
+
+	myError := errors.New("done")
+	g.AddWith(func() error {
+		if want, have := "v1", config.Load(); want != have {
+			return errors.New("want v1, have " + have)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		values := config.Watch(ctx)
+		if want, have := "v1", <-values; want != have {
+			return errors.New("watch: want v1, have " + have)
+		}
+
+		close(next)
+		if want, have := "v2", <-values; want != have {
+			return errors.New("watch: want v2, have " + have)
+		}
+
+		cancel()
+		for range values {
+		}
+
+		return myError
+	}, nil, config)
+
+	if want, have := myError, g.Run(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,7 @@
+// Package winsvc runs a deprun.Group as a Windows service. The group is
+// started when the service control manager starts the service, a stop or
+// shutdown request tears it down, and service state transitions
+// (start pending, running, stop pending) follow the state of the group.
+//
+// The package only provides functionality on Windows.
+package winsvc
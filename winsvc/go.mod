@@ -0,0 +1,8 @@
+module github.com/istovpets/deprun/winsvc
+
+go 1.25.3
+
+require (
+	github.com/istovpets/deprun v0.0.0-20261016024451-dd9d13423e15
+	golang.org/x/sys v0.40.0
+)
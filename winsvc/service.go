@@ -0,0 +1,138 @@
+//go:build windows
+
+package winsvc
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/istovpets/deprun"
+	"golang.org/x/sys/windows/svc"
+)
+
+// ErrStopRequested is the error the group is torn down with when the service
+// control manager asks the service to stop or the system shuts down.
+var ErrStopRequested = errors.New("winsvc: stop requested")
+
+// Handler adapts a deprun.Group to svc.Handler.
+type Handler struct {
+	group    *deprun.Group
+	err      error
+	executed atomic.Bool
+
+	mu      sync.Mutex
+	status  svc.Status
+	changes chan<- svc.Status
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewHandler returns a Handler running g, and adds to g the actors
+// reporting its state to the service control manager. The group must not
+// be run by other means, and Execute runs it once.
+func NewHandler(g *deprun.Group) *Handler {
+	h := &Handler{
+		group:  g,
+		status: svc.Status{State: svc.StartPending},
+		stop:   make(chan struct{}),
+	}
+
+	// Tear the group down on request, and report teardown however it starts.
+	g.Add(func() error {
+		<-h.stop
+		return ErrStopRequested
+	}, func(error) {
+		h.report(svc.Status{State: svc.StopPending})
+		h.requestStop()
+	})
+
+	// Report running once the group is ready.
+	running := make(chan struct{})
+	g.Add(func() error {
+		h.report(svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown})
+		<-running
+		return nil
+	}, func(error) {
+		close(running)
+	}, g.Ready())
+
+	return h
+}
+
+// Err returns the error the group terminated with, once Execute has returned.
+func (h *Handler) Err() error {
+	return h.err
+}
+
+// report sends s to the service control manager, unless the service is
+// already stopping.
+func (h *Handler) report(s svc.Status) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Never go back from stopping to running.
+	if h.status.State == svc.StopPending && s.State != svc.StopPending {
+		return
+	}
+
+	h.status = s
+	h.changes <- s
+}
+
+func (h *Handler) requestStop() {
+	h.stopOnce.Do(func() { close(h.stop) })
+}
+
+// Execute implements svc.Handler. The service reports StartPending until
+// every actor added with AddDep is ready, then Running. Stop and shutdown
+// requests tear the group down with ErrStopRequested. A group terminating
+// with any other error reports a service-specific exit code of 1, as does
+// calling Execute again: a group runs only once.
+func (h *Handler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	if h.executed.Swap(true) {
+		return true, 1
+	}
+
+	h.mu.Lock()
+	h.changes = changes
+	changes <- h.status
+	h.mu.Unlock()
+
+	res := make(chan error, 1)
+	go func() { res <- h.group.Run() }()
+
+	for {
+		select {
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				h.mu.Lock()
+				changes <- h.status
+				h.mu.Unlock()
+			case svc.Stop, svc.Shutdown:
+				h.report(svc.Status{State: svc.StopPending})
+				h.requestStop()
+			}
+		case h.err = <-res:
+			if h.err == nil || errors.Is(h.err, ErrStopRequested) {
+				return false, 0
+			}
+
+			return true, 1
+		}
+	}
+}
+
+// Run runs g as the Windows service name and returns the error the group
+// terminated with. It must be called from a process started by the service
+// control manager; see svc.IsWindowsService.
+func Run(name string, g *deprun.Group) error {
+	h := NewHandler(g)
+	if err := svc.Run(name, h); err != nil {
+		return err
+	}
+
+	return h.Err()
+}
@@ -0,0 +1,78 @@
+//go:build windows
+
+package winsvc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/istovpets/deprun"
+	"github.com/istovpets/deprun/winsvc"
+	"golang.org/x/sys/windows/svc"
+)
+
+func expect(t *testing.T, changes <-chan svc.Status, want svc.State) {
+	t.Helper()
+
+	select {
+	case s := <-changes:
+		if s.State != want {
+			t.Fatalf("want state %v, have %v", want, s.State)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout waiting for state %v", want)
+	}
+}
+
+func TestHandlerStop(t *testing.T) {
+	var g deprun.Group
+	release := make(chan struct{})
+	g.AddDep(func(ready deprun.ReadySignal) error {
+		<-release
+		ready()
+		<-release
+		return nil
+	}, func(error) {})
+
+	h := winsvc.NewHandler(&g)
+	requests := make(chan svc.ChangeRequest)
+	changes := make(chan svc.Status, 16)
+
+	type result struct {
+		ssec  bool
+		errno uint32
+	}
+	res := make(chan result, 1)
+	go func() {
+		ssec, errno := h.Execute(nil, requests, changes)
+		res <- result{ssec, errno}
+	}()
+
+	expect(t, changes, svc.StartPending)
+	release <- struct{}{}
+	expect(t, changes, svc.Running)
+
+	requests <- svc.ChangeRequest{Cmd: svc.Stop}
+	expect(t, changes, svc.StopPending)
+	close(release)
+
+	if r := <-res; r.ssec || r.errno != 0 {
+		t.Errorf("want clean exit, have %v, %d", r.ssec, r.errno)
+	}
+}
+
+func TestHandlerExecuteOnce(t *testing.T) {
+	var g deprun.Group
+	h := winsvc.NewHandler(&g)
+
+	requests := make(chan svc.ChangeRequest, 1)
+	changes := make(chan svc.Status, 16)
+	requests <- svc.ChangeRequest{Cmd: svc.Stop}
+	if ssec, errno := h.Execute(nil, requests, changes); ssec || errno != 0 {
+		t.Fatalf("want clean exit, have %v, %d", ssec, errno)
+	}
+
+	if ssec, errno := h.Execute(nil, requests, changes); !ssec || errno != 1 {
+		t.Errorf("want exit code 1 on a second Execute, have %v, %d", ssec, errno)
+	}
+}